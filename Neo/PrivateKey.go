@@ -194,6 +194,12 @@ func getScriptHashFromScript(script []byte) ([]byte) {
 	return pub_hash_2
 }
 
+func hash256(data []byte) ([]byte) {
+	hash1 := sha256.Sum256(data)
+	hash2 := sha256.Sum256(hash1[:])
+	return hash2[:]
+}
+
 func getAddressFromScriptHash(scriptHash []byte) (string, bool) {
 	length := len(scriptHash)
 	if length != 20 {
@@ -0,0 +1,107 @@
+package Neo
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AssetName returns the JSON name neo-gui registers for an asset with a
+// single name in lang, such as "en".
+func AssetName(lang, name string) string {
+	data, _ := json.Marshal([]map[string]string{{"lang": lang, "name": name}})
+	return string(data)
+}
+
+// NewRegisterTransData describes a new global asset. amount is the total
+// supply, or -Fixed8Satoshi for an unlimited one; owner is the public key
+// that issues the asset and admin the script hash that manages it.
+func NewRegisterTransData(assetType byte, name string, amount Fixed8, precision byte, owner []byte, admin UInt160) (*RegisterTransData, error) {
+	if _, ok := assetTypeNames[assetType]; !ok || assetType == AssetGoverningToken || assetType == AssetUtilityToken {
+		return nil, fmt.Errorf("asset type 0x%02x cannot be registered", assetType)
+	}
+	if len(name) > 1024 {
+		return nil, fmt.Errorf("asset name of %d bytes, at most 1024 allowed", len(name))
+	}
+	if precision > 8 {
+		return nil, fmt.Errorf("precision %d, at most 8 allowed", precision)
+	}
+	if amount.value != -Fixed8Satoshi.value {
+		if amount.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: asset amount %s", ErrBadAmount, amount)
+		}
+		unit := int64(1)
+		for p := precision; p < 8; p++ {
+			unit *= 10
+		}
+		if amount.value%unit != 0 {
+			return nil, fmt.Errorf("%w: asset amount %s exceeds precision %d", ErrBadAmount, amount, precision)
+		}
+	}
+	ownerKey, err := compressPublicKey(owner)
+	if err != nil {
+		return nil, err
+	}
+	return &RegisterTransData{
+		assetType: assetType,
+		name:      name,
+		amount:    amount,
+		precision: precision,
+		owner:     ownerKey,
+		admin:     admin,
+	}, nil
+}
+
+func (self *RegisterTransData) AssetType() byte {
+	return self.assetType
+}
+
+func (self *RegisterTransData) Name() string {
+	return self.name
+}
+
+func (self *RegisterTransData) Amount() Fixed8 {
+	return self.amount
+}
+
+func (self *RegisterTransData) Precision() byte {
+	return self.precision
+}
+
+func (self *RegisterTransData) Owner() []byte {
+	return append([]byte(nil), self.owner...)
+}
+
+func (self *RegisterTransData) Admin() UInt160 {
+	return self.admin
+}
+
+// NewRegisterBuilder starts a RegisterTransaction for data. The
+// registration burns a system fee of 10000 GAS, so GAS utxos and a change
+// address have to be added before Build. Sign it with the owner key.
+func NewRegisterBuilder(data *RegisterTransData) *TransactionBuilder {
+	return NewTransactionBuilder(RegisterTransaction).SetExtData(data)
+}
+
+// NewIssueBuilder starts a version 1 IssueTransaction, which has no
+// system fee, with an output per payment. Sign it with the owner key of
+// the issued assets.
+func NewIssueBuilder(payments []Payment) *TransactionBuilder {
+	tb := NewTransactionBuilder(IssueTransaction).Version(1)
+	for _, payment := range payments {
+		tb.AddOutput(payment.AssetId, payment.Value, payment.Address)
+	}
+	return tb
+}
+
+// GetAssetId returns the id of the asset a RegisterTransaction creates,
+// which is the hash of the transaction.
+func (self *Transaction) GetAssetId() (UInt256, error) {
+	if self.txtype != RegisterTransaction {
+		return UInt256{}, fmt.Errorf("transaction type 0x%02x registers no asset", self.txtype)
+	}
+	hash, err := self.GetHash()
+	if err != nil {
+		return UInt256{}, err
+	}
+	return UInt256FromBytes(hash)
+}
@@ -0,0 +1,142 @@
+package Neo
+
+import (
+	"fmt"
+)
+
+func attributeLengthError(usage byte, length int) error {
+	return fmt.Errorf("%w: usage 0x%02x with %d bytes of data", ErrBadAttribute, usage, length)
+}
+
+// checkAttribute reports whether data fits the encoding of usage that
+// Attribute.Serialize writes.
+func checkAttribute(usage byte, data []byte) error {
+	size := len(data)
+	switch {
+	case usage == ContractHash || usage == Vote || (usage >= Hash1 && usage <= Hash15):
+		if size != 32 {
+			return attributeLengthError(usage, size)
+		}
+	case usage == ECDH02 || usage == ECDH03:
+		if size != 33 || data[0] != usage {
+			return attributeLengthError(usage, size)
+		}
+	case usage == Script:
+		if size != 20 {
+			return attributeLengthError(usage, size)
+		}
+	case usage == DescriptionUrl:
+		if size > 0xff {
+			return attributeLengthError(usage, size)
+		}
+	case usage == Description || usage >= Remark:
+		if size > 65535 {
+			return attributeLengthError(usage, size)
+		}
+	default:
+		return fmt.Errorf("%w: unknown usage 0x%02x", ErrBadAttribute, usage)
+	}
+	return nil
+}
+
+// NewAttribute checks data against usage and returns the attribute. ECDH
+// data is the whole 33 byte compressed key, prefix included.
+func NewAttribute(usage byte, data []byte) (Attribute, error) {
+	if err := checkAttribute(usage, data); err != nil {
+		return Attribute{}, err
+	}
+	return Attribute{usage: usage, data: append([]byte(nil), data...)}, nil
+}
+
+// NewScriptAttribute adds scriptHash to the hashes that must witness the
+// transaction.
+func NewScriptAttribute(scriptHash UInt160) Attribute {
+	return Attribute{usage: Script, data: scriptHash.Bytes()}
+}
+
+// NewRemarkAttribute returns a Remark attribute holding text.
+func NewRemarkAttribute(text string) (Attribute, error) {
+	return NewAttribute(Remark, []byte(text))
+}
+
+// NewRemarkBytesAttribute returns a remark with one of the usages Remark
+// to Remark15.
+func NewRemarkBytesAttribute(usage byte, data []byte) (Attribute, error) {
+	if usage < Remark {
+		return Attribute{}, fmt.Errorf("%w: 0x%02x is not a remark usage", ErrBadAttribute, usage)
+	}
+	return NewAttribute(usage, data)
+}
+
+// NewHashAttribute returns a ContractHash or Hash1 to Hash15 attribute.
+func NewHashAttribute(usage byte, hash UInt256) (Attribute, error) {
+	if usage != ContractHash && (usage < Hash1 || usage > Hash15) {
+		return Attribute{}, fmt.Errorf("%w: 0x%02x is not a hash usage", ErrBadAttribute, usage)
+	}
+	return Attribute{usage: usage, data: hash.Bytes()}, nil
+}
+
+// NewECDHAttribute takes a 33 byte compressed public key; its prefix
+// selects ECDH02 or ECDH03.
+func NewECDHAttribute(publicKey []byte) (Attribute, error) {
+	if len(publicKey) != 33 || (publicKey[0] != ECDH02 && publicKey[0] != ECDH03) {
+		return Attribute{}, fmt.Errorf("%w: not a compressed public key", ErrBadPublicKey)
+	}
+	return NewAttribute(publicKey[0], publicKey)
+}
+
+func NewVoteAttribute(hash UInt256) Attribute {
+	return Attribute{usage: Vote, data: hash.Bytes()}
+}
+
+func NewDescriptionAttribute(text string) (Attribute, error) {
+	return NewAttribute(Description, []byte(text))
+}
+
+// NewDescriptionUrlAttribute takes a URL of at most 255 bytes.
+func NewDescriptionUrlAttribute(url string) (Attribute, error) {
+	return NewAttribute(DescriptionUrl, []byte(url))
+}
+
+func (self *Attribute) Usage() byte {
+	return self.usage
+}
+
+// Data returns a copy of the attribute data. ECDH keys keep their prefix.
+func (self *Attribute) Data() []byte {
+	return append([]byte(nil), self.data...)
+}
+
+// ScriptHash returns the hash of a Script attribute.
+func (self *Attribute) ScriptHash() (UInt160, bool) {
+	if self.usage != Script {
+		return UInt160{}, false
+	}
+	hash, err := UInt160FromBytes(self.data)
+	return hash, err == nil
+}
+
+// Hash returns the hash of a ContractHash, Vote or Hash1 to Hash15
+// attribute.
+func (self *Attribute) Hash() (UInt256, bool) {
+	usage := self.usage
+	if usage != ContractHash && usage != Vote && (usage < Hash1 || usage > Hash15) {
+		return UInt256{}, false
+	}
+	hash, err := UInt256FromBytes(self.data)
+	return hash, err == nil
+}
+
+// Text returns the data of a Description, DescriptionUrl or remark
+// attribute as a string.
+func (self *Attribute) Text() (string, bool) {
+	if self.usage != Description && self.usage != DescriptionUrl && self.usage < Remark {
+		return "", false
+	}
+	return string(self.data), true
+}
+
+// Attributes returns a copy of the transaction attributes.
+func (self *Transaction) Attributes() []Attribute {
+	return append([]Attribute(nil), self.attributes...)
+}
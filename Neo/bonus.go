@@ -0,0 +1,112 @@
+package Neo
+
+import (
+	"fmt"
+)
+
+// GAS generation schedule of Neo 2: the GAS created per block drops every
+// decrementInterval blocks and stops after the last entry.
+const decrementInterval = 2000000
+
+var generationAmount = []uint64{8, 7, 6, 5, 4, 3, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}
+
+// SysFeeProvider returns the total system fee, in whole GAS, of the blocks
+// 0 through height, as the getblocksysfee RPC method does.
+type SysFeeProvider interface {
+	GetSysFeeAmount(height uint32) (int64, error)
+}
+
+// UnclaimedOutput is a NEO output held from the block StartHeight until
+// EndHeight. For an output that is spent EndHeight is the block that spent
+// it; for one still unspent it is the current height plus one, as the
+// getunclaimed RPC method counts.
+type UnclaimedOutput struct {
+	Value       Fixed8
+	StartHeight uint32
+	EndHeight   uint32
+	Spent       bool
+}
+
+// CalculateBonus returns the GAS that outputs generated, following
+// CalculateBonus of the reference implementation.
+func CalculateBonus(outputs []UnclaimedOutput, fees SysFeeProvider) (Fixed8, error) {
+	total := Fixed8Zero
+	for _, output := range outputs {
+		bonus, err := outputBonus(output, fees)
+		if err != nil {
+			return Fixed8{}, err
+		}
+		if total, err = total.Add(bonus); err != nil {
+			return Fixed8{}, err
+		}
+	}
+	return total, nil
+}
+
+// CalculateUnclaimed splits the GAS generated by outputs into available
+// GAS, from spent outputs that can be claimed now, and unavailable GAS,
+// from outputs that have to be spent first.
+func CalculateUnclaimed(outputs []UnclaimedOutput, fees SysFeeProvider) (available Fixed8, unavailable Fixed8, err error) {
+	var spent, unspent []UnclaimedOutput
+	for _, output := range outputs {
+		if output.Spent {
+			spent = append(spent, output)
+		} else {
+			unspent = append(unspent, output)
+		}
+	}
+	if available, err = CalculateBonus(spent, fees); err != nil {
+		return Fixed8{}, Fixed8{}, err
+	}
+	if unavailable, err = CalculateBonus(unspent, fees); err != nil {
+		return Fixed8{}, Fixed8{}, err
+	}
+	return available, unavailable, nil
+}
+
+func outputBonus(output UnclaimedOutput, fees SysFeeProvider) (Fixed8, error) {
+	start, end := output.StartHeight, output.EndHeight
+	if end < start {
+		return Fixed8{}, fmt.Errorf("output held from block %d until block %d", start, end)
+	}
+	if end == start {
+		return Fixed8Zero, nil
+	}
+
+	var amount uint64
+	ustart := uint64(start / decrementInterval)
+	if ustart < uint64(len(generationAmount)) {
+		istart := uint64(start % decrementInterval)
+		uend := uint64(end / decrementInterval)
+		iend := uint64(end % decrementInterval)
+		if uend >= uint64(len(generationAmount)) {
+			uend = uint64(len(generationAmount))
+			iend = 0
+		}
+		if iend == 0 {
+			uend--
+			iend = decrementInterval
+		}
+		for ustart < uend {
+			amount += (decrementInterval - istart) * generationAmount[ustart]
+			ustart++
+			istart = 0
+		}
+		amount += (iend - istart) * generationAmount[ustart]
+	}
+
+	endFee, err := fees.GetSysFeeAmount(end - 1)
+	if err != nil {
+		return Fixed8{}, err
+	}
+	var startFee int64
+	if start > 0 {
+		if startFee, err = fees.GetSysFeeAmount(start - 1); err != nil {
+			return Fixed8{}, err
+		}
+	}
+	amount += uint64(endFee - startFee)
+
+	// one NEO earns amount units of 0.00000001 GAS
+	return Fixed8{value: output.Value.value / D}.MulInt(int64(amount))
+}
@@ -0,0 +1,87 @@
+package Neo
+
+import (
+	"errors"
+	"testing"
+)
+
+// feeTable returns a cumulative system fee of perBlock GAS for every block
+// from 0 to height.
+type feeTable struct {
+	perBlock int64
+	err      error
+}
+
+func (f feeTable) GetSysFeeAmount(height uint32) (int64, error) {
+	return f.perBlock * (int64(height) + 1), f.err
+}
+
+func TestCalculateBonus(t *testing.T) {
+	neo := Fixed8{value: D}
+	tests := []struct {
+		name       string
+		value      Fixed8
+		start, end uint32
+		perBlock   int64
+		want       int64 // in units of 0.00000001 GAS
+	}{
+		{"one block", neo, 0, 1, 0, 8},
+		{"held for no block", neo, 100, 100, 0, 0},
+		{"whole first period", neo, 0, 2000000, 0, 8 * 2000000},
+		{"across a decrement", neo, 1999999, 2000001, 0, 8 + 7},
+		{"ending on a decrement", neo, 1999999, 4000000, 0, 8 + 7*2000000},
+		{"several periods", neo, 1000000, 5000000, 0, 8*1000000 + 7*2000000 + 6*1000000},
+		{"end past the last period", neo, 43000000, 50000000, 0, 1000000},
+		{"start past the last period", neo, 44000000, 45000000, 0, 0},
+		{"system fees", neo, 10, 20, 3, 8*10 + 3*10},
+		{"system fees from genesis", neo, 0, 20, 3, 8*20 + 3*20},
+		{"system fees after the last period", neo, 44000000, 44000010, 2, 2 * 10},
+		{"fractions of NEO are not counted", Fixed8{value: 2*D + D/2}, 0, 1, 0, 2 * 8},
+		// the whole NEO supply held through the schedule generates all GAS
+		{"total supply", Fixed8{value: 100000000 * D}, 0, 44000000, 0, 100000000 * D},
+		{"total supply past the end", Fixed8{value: 100000000 * D}, 0, 50000000, 0, 100000000 * D},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outputs := []UnclaimedOutput{{Value: test.value, StartHeight: test.start, EndHeight: test.end}}
+			bonus, err := CalculateBonus(outputs, feeTable{perBlock: test.perBlock})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bonus.value != test.want {
+				t.Errorf("bonus %s, want %s", bonus, Fixed8{value: test.want})
+			}
+		})
+	}
+}
+
+func TestCalculateBonusErrors(t *testing.T) {
+	neo := Fixed8{value: D}
+	if _, err := CalculateBonus([]UnclaimedOutput{{Value: neo, StartHeight: 20, EndHeight: 10}}, feeTable{}); err == nil {
+		t.Error("no error for an output spent before it was created")
+	}
+	feeErr := errors.New("no fee")
+	outputs := []UnclaimedOutput{{Value: neo, StartHeight: 0, EndHeight: 10}}
+	if _, err := CalculateBonus(outputs, feeTable{err: feeErr}); !errors.Is(err, feeErr) {
+		t.Errorf("got %v, want the fee provider error", err)
+	}
+}
+
+func TestCalculateUnclaimed(t *testing.T) {
+	neo := Fixed8{value: D}
+	outputs := []UnclaimedOutput{
+		{Value: neo, StartHeight: 0, EndHeight: 10, Spent: true},
+		{Value: neo, StartHeight: 5, EndHeight: 10, Spent: true},
+		{Value: neo, StartHeight: 1999990, EndHeight: 2000010},
+	}
+	available, unavailable, err := CalculateUnclaimed(outputs, feeTable{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if available.value != 8*10+8*5 {
+		t.Errorf("available %s", available)
+	}
+	if unavailable.value != 8*10+7*10 {
+		t.Errorf("unavailable %s", unavailable)
+	}
+}
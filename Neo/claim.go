@@ -0,0 +1,92 @@
+package Neo
+
+import (
+	"fmt"
+)
+
+// Claim is a spent NEO output together with the GAS it can claim.
+type Claim struct {
+	Hash  UInt256
+	N     uint16
+	Value Fixed8
+}
+
+func NewClaimTransData(claims []TransactionInput) *ClaimTransData {
+	return &ClaimTransData{claims: append([]TransactionInput(nil), claims...)}
+}
+
+// NewClaimTransaction returns an unsigned ClaimTransaction that claims
+// amount GAS from the spent outputs in claims to address.
+func NewClaimTransaction(claims []TransactionInput, amount Fixed8, address string) (*Transaction, error) {
+	if len(claims) == 0 {
+		return nil, fmt.Errorf("claim transaction without claims")
+	}
+	seen := make(map[TransactionInput]bool, len(claims))
+	for _, claim := range claims {
+		if seen[claim] {
+			return nil, fmt.Errorf("duplicate claim %s:%d", claim.hash, claim.index)
+		}
+		seen[claim] = true
+	}
+	return NewTransactionBuilder(ClaimTransaction).
+		SetExtData(NewClaimTransData(claims)).
+		AddOutput(GasAssetHash, amount, address).
+		Build()
+}
+
+// claimLimit returns how many claims fit in one transaction of at most
+// maxSize bytes once signed.
+func claimLimit(address string, maxSize int) (int, error) {
+	tx, err := NewClaimTransaction([]TransactionInput{{}}, Fixed8Satoshi, address)
+	if err != nil {
+		return 0, err
+	}
+	size, err := tx.EstimateSize(SingleSigWitnessSize())
+	if err != nil {
+		return 0, err
+	}
+	return inputLimit(size, maxSize), nil
+}
+
+// ClaimGas claims the GAS of claims to the address of wif, signing the
+// transactions with its key. At most maxClaims claims go in one
+// transaction; with maxClaims 0 each transaction is kept within the 1024
+// bytes that nodes relay without a network fee.
+func ClaimGas(wif string, claims []Claim, maxClaims int) ([]SignedTransaction, error) {
+	privKey, err := privateKeyFromWIF(wif)
+	if err != nil {
+		return nil, err
+	}
+	address := getAddressFromPublicKey(&privKey.PublicKey)
+	if maxClaims <= 0 {
+		if maxClaims, err = claimLimit(address, DefaultFeePolicy().FreeSize); err != nil {
+			return nil, err
+		}
+	}
+
+	var signed []SignedTransaction
+	for start := 0; start < len(claims); start += maxClaims {
+		chunk := claims[start:]
+		if len(chunk) > maxClaims {
+			chunk = chunk[:maxClaims]
+		}
+		inputs := make([]TransactionInput, len(chunk))
+		amount := Fixed8Zero
+		for i, claim := range chunk {
+			inputs[i] = NewTransactionInput(claim.Hash, claim.N)
+			if amount, err = amount.Add(claim.Value); err != nil {
+				return signed, err
+			}
+		}
+		tx, err := NewClaimTransaction(inputs, amount, address)
+		if err != nil {
+			return signed, err
+		}
+		result, err := signTransaction(tx, privKey)
+		if err != nil {
+			return signed, err
+		}
+		signed = append(signed, result)
+	}
+	return signed, nil
+}
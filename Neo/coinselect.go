@@ -0,0 +1,304 @@
+package Neo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// CoinSelector picks utxos of one asset worth at least target. maxInputs
+// limits the number of utxos picked; 0 means no limit.
+type CoinSelector interface {
+	Select(utxos []Utxo, target Fixed8, maxInputs int) ([]Utxo, error)
+}
+
+func sumUtxos(utxos []Utxo) (Fixed8, error) {
+	sum := Fixed8Zero
+	for _, utxo := range utxos {
+		var err error
+		if sum, err = sum.Add(utxo.Value); err != nil {
+			return Fixed8{}, err
+		}
+	}
+	return sum, nil
+}
+
+// sortedUtxos returns a copy of utxos ordered by value, keeping the
+// original order between equal values.
+func sortedUtxos(utxos []Utxo, descending bool) []Utxo {
+	sorted := append([]Utxo(nil), utxos...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if descending {
+			return sorted[i].Value.GreaterThan(sorted[j].Value)
+		}
+		return sorted[i].Value.LessThan(sorted[j].Value)
+	})
+	return sorted
+}
+
+func insufficient(utxos []Utxo, target Fixed8) error {
+	total, _ := sumUtxos(utxos)
+	return fmt.Errorf("%w: have %s, need %s", ErrInsufficientFunds, total, target)
+}
+
+// LargestFirst spends the biggest utxos first, which keeps the input count
+// low.
+type LargestFirst struct{}
+
+func (LargestFirst) Select(utxos []Utxo, target Fixed8, maxInputs int) ([]Utxo, error) {
+	if target.Sign() <= 0 {
+		return nil, nil
+	}
+	sorted := sortedUtxos(utxos, true)
+	sum := Fixed8Zero
+	for i, utxo := range sorted {
+		if maxInputs > 0 && i == maxInputs {
+			return nil, fmt.Errorf("%w: %s needs more than %d inputs", ErrTooManyInputs, target, maxInputs)
+		}
+		sum, _ = sum.Add(utxo.Value)
+		if !sum.LessThan(target) {
+			return sorted[:i+1], nil
+		}
+	}
+	return nil, insufficient(utxos, target)
+}
+
+// SmallestFirst spends the smallest utxos first to clean up dust. When
+// that needs more than maxInputs, the smallest picked utxos are swapped
+// for larger ones.
+type SmallestFirst struct{}
+
+func (SmallestFirst) Select(utxos []Utxo, target Fixed8, maxInputs int) ([]Utxo, error) {
+	if target.Sign() <= 0 {
+		return nil, nil
+	}
+	sorted := sortedUtxos(utxos, false)
+	start := 0
+	sum := Fixed8Zero
+	for end, utxo := range sorted {
+		sum, _ = sum.Add(utxo.Value)
+		if maxInputs > 0 && end-start+1 > maxInputs {
+			sum, _ = sum.Sub(sorted[start].Value)
+			start++
+		}
+		if !sum.LessThan(target) {
+			return sorted[start : end+1], nil
+		}
+	}
+	if maxInputs > 0 && len(utxos) > maxInputs {
+		if total, _ := sumUtxos(utxos); !total.LessThan(target) {
+			return nil, fmt.Errorf("%w: %s needs more than %d inputs", ErrTooManyInputs, target, maxInputs)
+		}
+	}
+	return nil, insufficient(utxos, target)
+}
+
+// BranchAndBound searches for utxos that add up to exactly target, so the
+// transaction needs no change output. Without an exact match within
+// MaxTries steps it uses Fallback, or LargestFirst when that is nil.
+type BranchAndBound struct {
+	MaxTries int
+	Fallback CoinSelector
+}
+
+func (bnb BranchAndBound) Select(utxos []Utxo, target Fixed8, maxInputs int) ([]Utxo, error) {
+	if target.Sign() <= 0 {
+		return nil, nil
+	}
+	sorted := sortedUtxos(utxos, true)
+	// remaining[i] is the value of sorted[i:]
+	remaining := make([]int64, len(sorted)+1)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + sorted[i].Value.value
+		if remaining[i] < remaining[i+1] {
+			remaining[i] = math.MaxInt64 // saturate on overflow
+		}
+	}
+
+	tries := bnb.MaxTries
+	if tries <= 0 {
+		tries = 100000
+	}
+	var picked []int
+	var search func(i int, need int64) bool
+	search = func(i int, need int64) bool {
+		if need == 0 {
+			return true
+		}
+		if tries--; tries < 0 || i == len(sorted) || remaining[i] < need {
+			return false
+		}
+		if maxInputs > 0 && len(picked) == maxInputs {
+			return false
+		}
+		if value := sorted[i].Value.value; value <= need {
+			picked = append(picked, i)
+			if search(i+1, need-value) {
+				return true
+			}
+			picked = picked[:len(picked)-1]
+		}
+		return search(i+1, need)
+	}
+	if search(0, target.value) {
+		selected := make([]Utxo, len(picked))
+		for k, i := range picked {
+			selected[k] = sorted[i]
+		}
+		return selected, nil
+	}
+
+	fallback := bnb.Fallback
+	if fallback == nil {
+		fallback = LargestFirst{}
+	}
+	return fallback.Select(utxos, target, maxInputs)
+}
+
+// RandomSelector spends utxos in random order so payments reveal less
+// about the wallet. Rand may be nil to use the math/rand default source.
+// When a random pick needs too many inputs it falls back to LargestFirst.
+type RandomSelector struct {
+	Rand *rand.Rand
+}
+
+func (rs RandomSelector) Select(utxos []Utxo, target Fixed8, maxInputs int) ([]Utxo, error) {
+	if target.Sign() <= 0 {
+		return nil, nil
+	}
+	shuffled := append([]Utxo(nil), utxos...)
+	swap := func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] }
+	if rs.Rand != nil {
+		rs.Rand.Shuffle(len(shuffled), swap)
+	} else {
+		rand.Shuffle(len(shuffled), swap)
+	}
+	sum := Fixed8Zero
+	for i, utxo := range shuffled {
+		if maxInputs > 0 && i == maxInputs {
+			return LargestFirst{}.Select(utxos, target, maxInputs)
+		}
+		sum, _ = sum.Add(utxo.Value)
+		if !sum.LessThan(target) {
+			return shuffled[:i+1], nil
+		}
+	}
+	return nil, insufficient(utxos, target)
+}
+
+func sortedAssets(amounts map[UInt256]Fixed8) []UInt256 {
+	assets := make([]UInt256, 0, len(amounts))
+	for assetId := range amounts {
+		assets = append(assets, assetId)
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Less(assets[j]) })
+	return assets
+}
+
+// CoinSelection picks the inputs of a whole transaction. MaxInputs bounds
+// the inputs over all assets and Fee is GAS to spend on top of the GAS
+// outputs, for the system and network fees.
+type CoinSelection struct {
+	Selector  CoinSelector
+	MaxInputs int
+	Fee       Fixed8
+}
+
+// Select picks utxos, grouped by asset, that cover targets plus the fee
+// in GAS. Assets are handled in a fixed order, so the input limit is
+// shared deterministically.
+func (cs *CoinSelection) Select(utxos map[UInt256][]Utxo, targets map[UInt256]Fixed8) (map[UInt256][]Utxo, error) {
+	selector := cs.Selector
+	if selector == nil {
+		selector = LargestFirst{}
+	}
+	if cs.Fee.Sign() > 0 {
+		gas, err := targets[GasAssetHash].Add(cs.Fee)
+		if err != nil {
+			return nil, err
+		}
+		withFee := make(map[UInt256]Fixed8, len(targets)+1)
+		for assetId, target := range targets {
+			withFee[assetId] = target
+		}
+		withFee[GasAssetHash] = gas
+		targets = withFee
+	}
+
+	selected := make(map[UInt256][]Utxo)
+	used := 0
+	for _, assetId := range sortedAssets(targets) {
+		if targets[assetId].Sign() <= 0 {
+			continue
+		}
+		limit := 0
+		if cs.MaxInputs > 0 {
+			limit = cs.MaxInputs - used
+			if limit <= 0 {
+				return nil, fmt.Errorf("%w: no inputs left for asset %s", ErrTooManyInputs, assetId)
+			}
+		}
+		picked, err := selector.Select(utxos[assetId], targets[assetId], limit)
+		if err != nil {
+			return nil, fmt.Errorf("asset %s: %w", assetId, err)
+		}
+		if len(picked) > 0 {
+			selected[assetId] = picked
+			used += len(picked)
+		}
+	}
+	return selected, nil
+}
+
+// SelectInputs picks inputs from utxos for the outputs added so far, the
+// system fee, the network fee set with NetworkFee and selection.Fee, and
+// adds them with AddUtxo. With AutoNetworkFee, Build picks more GAS from
+// utxos when the fee it settles on is larger.
+func (tb *TransactionBuilder) SelectInputs(selection *CoinSelection, utxos map[UInt256][]Utxo) *TransactionBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	targets := make(map[UInt256]Fixed8, len(tb.outSums)+1)
+	for assetId, out := range tb.outSums {
+		targets[assetId] = out
+	}
+	fees, err := tb.tx.GetSystemFee().Add(tb.networkFee)
+	if err == nil && fees.Sign() > 0 {
+		targets[GasAssetHash], err = targets[GasAssetHash].Add(fees)
+	}
+	if err != nil {
+		return tb.fail(err)
+	}
+	for assetId, in := range tb.inSums {
+		if targets[assetId], err = targets[assetId].Sub(in); err != nil {
+			return tb.fail(err)
+		}
+	}
+
+	limited := *selection
+	if limited.MaxInputs > 0 {
+		if limited.MaxInputs -= len(tb.tx.inputs); limited.MaxInputs <= 0 {
+			return tb.fail(fmt.Errorf("%w: %d inputs already added", ErrTooManyInputs, len(tb.tx.inputs)))
+		}
+	}
+	selected, err := limited.Select(utxos, targets)
+	if err != nil {
+		return tb.fail(err)
+	}
+	tb.selection = selection
+	tb.utxos = utxos
+	// selected also holds the GAS picked for selection.Fee, which targets
+	// lacks when no output is in GAS
+	assets := make([]UInt256, 0, len(selected))
+	for assetId := range selected {
+		assets = append(assets, assetId)
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Less(assets[j]) })
+	for _, assetId := range assets {
+		for _, utxo := range selected[assetId] {
+			tb.AddUtxo(assetId, utxo)
+		}
+	}
+	return tb
+}
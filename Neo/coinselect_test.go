@@ -0,0 +1,145 @@
+package Neo
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// coins returns utxos worth the given whole amounts, each from its own
+// transaction.
+func coins(values ...int64) []Utxo {
+	utxos := testUtxos(len(values), 0)
+	for i, value := range values {
+		utxos[i].Value = Fixed8{value: value * D}
+	}
+	return utxos
+}
+
+func coinValues(utxos []Utxo) []int64 {
+	values := make([]int64, len(utxos))
+	for i, utxo := range utxos {
+		values[i] = utxo.Value.value / D
+	}
+	return values
+}
+
+func equalValues(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCoinSelectors(t *testing.T) {
+	utxos := coins(3, 1, 5, 2)
+	tests := []struct {
+		name      string
+		selector  CoinSelector
+		target    int64
+		maxInputs int
+		want      []int64
+		err       error
+	}{
+		{"largest first exact", LargestFirst{}, 5, 0, []int64{5}, nil},
+		{"largest first change", LargestFirst{}, 6, 0, []int64{5, 3}, nil},
+		{"largest first insufficient", LargestFirst{}, 12, 0, nil, ErrInsufficientFunds},
+		{"largest first too many inputs", LargestFirst{}, 9, 2, nil, ErrTooManyInputs},
+		{"largest first within limit", LargestFirst{}, 8, 2, []int64{5, 3}, nil},
+
+		{"smallest first exact", SmallestFirst{}, 3, 0, []int64{1, 2}, nil},
+		{"smallest first change", SmallestFirst{}, 4, 0, []int64{1, 2, 3}, nil},
+		{"smallest first insufficient", SmallestFirst{}, 12, 0, nil, ErrInsufficientFunds},
+		{"smallest first too many inputs", SmallestFirst{}, 9, 2, nil, ErrTooManyInputs},
+		{"smallest first swaps dust for larger", SmallestFirst{}, 7, 2, []int64{3, 5}, nil},
+		{"smallest first insufficient under limit", SmallestFirst{}, 12, 2, nil, ErrInsufficientFunds},
+
+		{"branch and bound exact", BranchAndBound{}, 7, 0, []int64{5, 2}, nil},
+		{"branch and bound exact of small", BranchAndBound{}, 4, 0, []int64{3, 1}, nil},
+		{"branch and bound exact within limit", BranchAndBound{}, 3, 1, []int64{3}, nil},
+		{"branch and bound falls back to largest first", BranchAndBound{}, 4, 1, []int64{5}, nil},
+		{"branch and bound insufficient", BranchAndBound{}, 12, 0, nil, ErrInsufficientFunds},
+		{"branch and bound too many inputs", BranchAndBound{}, 9, 2, nil, ErrTooManyInputs},
+		{"branch and bound out of tries", BranchAndBound{MaxTries: 1, Fallback: SmallestFirst{}}, 7, 0, []int64{1, 2, 3, 5}, nil},
+
+		{"random falls back within limit", RandomSelector{Rand: rand.New(rand.NewSource(1))}, 5, 1, []int64{5}, nil},
+		{"random insufficient", RandomSelector{Rand: rand.New(rand.NewSource(1))}, 12, 0, nil, ErrInsufficientFunds},
+		{"random too many inputs", RandomSelector{Rand: rand.New(rand.NewSource(1))}, 9, 2, nil, ErrTooManyInputs},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selected, err := test.selector.Select(utxos, Fixed8{value: test.target * D}, test.maxInputs)
+			if test.err != nil {
+				if !errors.Is(err, test.err) {
+					t.Errorf("got %v, want %v", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := coinValues(selected); !equalValues(got, test.want) {
+				t.Errorf("selected %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRandomSelector(t *testing.T) {
+	utxos := coins(3, 1, 5, 2, 4, 6)
+	for seed := int64(0); seed < 20; seed++ {
+		selected, err := RandomSelector{Rand: rand.New(rand.NewSource(seed))}.Select(utxos, Fixed8{value: 8 * D}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen := make(map[UInt256]bool)
+		for _, utxo := range selected {
+			if seen[utxo.Hash] {
+				t.Fatalf("seed %d: utxo %s picked twice", seed, utxo.Hash)
+			}
+			seen[utxo.Hash] = true
+		}
+		sum, _ := sumUtxos(selected)
+		// dropping the last pick must leave the target uncovered
+		last, _ := sum.Sub(selected[len(selected)-1].Value)
+		if sum.LessThan(Fixed8{value: 8 * D}) || !last.LessThan(Fixed8{value: 8 * D}) {
+			t.Errorf("seed %d: selected %v", seed, coinValues(selected))
+		}
+	}
+}
+
+func TestCoinSelectionSharesInputLimit(t *testing.T) {
+	neo := coins(1, 1, 1)
+	gas := coins(2, 2)
+	utxos := map[UInt256][]Utxo{NeoAssetHash: neo, GasAssetHash: gas}
+	targets := map[UInt256]Fixed8{NeoAssetHash: {value: 2 * D}, GasAssetHash: {value: 1 * D}}
+
+	selection := &CoinSelection{MaxInputs: 3}
+	selected, err := selection.Select(utxos, targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selected[NeoAssetHash]) != 2 || len(selected[GasAssetHash]) != 1 {
+		t.Errorf("selected %d NEO and %d GAS inputs", len(selected[NeoAssetHash]), len(selected[GasAssetHash]))
+	}
+
+	selection.MaxInputs = 2
+	if _, err := selection.Select(utxos, targets); !errors.Is(err, ErrTooManyInputs) {
+		t.Errorf("got %v, want ErrTooManyInputs", err)
+	}
+
+	// the fee is picked in GAS on top of the GAS target
+	selection = &CoinSelection{Fee: Fixed8{value: 3 * D}}
+	selected, err = selection.Select(utxos, targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := coinValues(selected[GasAssetHash]); !equalValues(got, []int64{2, 2}) {
+		t.Errorf("GAS selected %v, want [2 2]", got)
+	}
+}
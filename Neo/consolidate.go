@@ -0,0 +1,124 @@
+package Neo
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"sort"
+)
+
+func privateKeyFromWIF(wif string) (*ecdsa.PrivateKey, error) {
+	privKey := &ecdsa.PrivateKey{}
+	if err := FromWIF(privKey, wif); err != nil {
+		return nil, err
+	}
+	return privKey, nil
+}
+
+// mergeInputLimit returns how many utxos one transaction merging them
+// into a single output can spend without its signed size passing
+// maxSize.
+func mergeInputLimit(assetId UInt256, utxo Utxo, to string, maxSize int) (int, error) {
+	tx, err := NewTransactionBuilder(ContractTransaction).
+		AddUtxo(assetId, utxo).
+		SetChangeAddress(to).
+		Build()
+	if err != nil {
+		return 0, err
+	}
+	size, err := tx.EstimateSize(SingleSigWitnessSize())
+	if err != nil {
+		return 0, err
+	}
+	if size > maxSize {
+		return 0, fmt.Errorf("a single input makes a transaction of %d bytes", size)
+	}
+	return inputLimit(size, maxSize), nil
+}
+
+// mergeUtxos moves utxos of assetId to one output at to per transaction,
+// chunked under maxSize. GAS pays its own network fee under policy; other
+// assets are chunked under the policy's free size instead, so they need
+// no fee at all. Trailing chunks smaller than minInputs are left alone.
+func mergeUtxos(privKey *ecdsa.PrivateKey, assetId UInt256, utxos []Utxo, to string, policy *FeePolicy, maxSize int, minInputs int) ([]SignedTransaction, error) {
+	if len(utxos) == 0 {
+		return nil, nil
+	}
+	if maxSize <= 0 {
+		maxSize = 102400
+	}
+	if policy != nil && assetId != GasAssetHash && policy.FreeSize < maxSize {
+		maxSize = policy.FreeSize
+	}
+	limit, err := mergeInputLimit(assetId, utxos[0], to, maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var signed []SignedTransaction
+	for start := 0; start < len(utxos); start += limit {
+		chunk := utxos[start:]
+		if len(chunk) > limit {
+			chunk = chunk[:limit]
+		}
+		if len(chunk) < minInputs {
+			break
+		}
+		tb := NewTransactionBuilder(ContractTransaction)
+		for _, utxo := range chunk {
+			tb.AddUtxo(assetId, utxo)
+		}
+		tx, err := tb.SetChangeAddress(to).
+			AutoNetworkFee(policy, SingleSigWitnessSize()).
+			Build()
+		if err != nil {
+			return signed, err
+		}
+		result, err := signTransaction(tx, privKey)
+		if err != nil {
+			return signed, err
+		}
+		signed = append(signed, result)
+	}
+	return signed, nil
+}
+
+// Consolidate merges the utxos of assetId held by the address of wif into
+// one output per transaction, each transaction staying under maxSize
+// bytes (102400 when 0). Utxos are merged in the given order. Under a fee
+// policy GAS pays its network fee out of the merged value, while other
+// assets are chunked under the free size so that they need no fee.
+func Consolidate(wif string, assetId UInt256, utxos []Utxo, policy *FeePolicy, maxSize int) ([]SignedTransaction, error) {
+	privKey, err := privateKeyFromWIF(wif)
+	if err != nil {
+		return nil, err
+	}
+	address := getAddressFromPublicKey(&privKey.PublicKey)
+	return mergeUtxos(privKey, assetId, utxos, address, policy, maxSize, 2)
+}
+
+// Sweep moves every utxo in utxos, of every asset, from the address of
+// wif to the address to. Each transaction carries a single asset and the
+// same size and fee rules as Consolidate apply.
+func Sweep(wif string, to string, utxos map[UInt256][]Utxo, policy *FeePolicy, maxSize int) ([]SignedTransaction, error) {
+	privKey, err := privateKeyFromWIF(wif)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := UInt160FromAddress(to); err != nil {
+		return nil, err
+	}
+	assets := make([]UInt256, 0, len(utxos))
+	for assetId := range utxos {
+		assets = append(assets, assetId)
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Less(assets[j]) })
+	var signed []SignedTransaction
+	for _, assetId := range assets {
+		result, err := mergeUtxos(privKey, assetId, utxos[assetId], to, policy, maxSize, 1)
+		signed = append(signed, result...)
+		if err != nil {
+			return signed, fmt.Errorf("asset %s: %w", assetId, err)
+		}
+	}
+	return signed, nil
+}
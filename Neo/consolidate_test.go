@@ -0,0 +1,183 @@
+package Neo
+
+import (
+	"testing"
+)
+
+func TestInputLimit(t *testing.T) {
+	const size = 200
+	tests := []struct {
+		maxSize int
+		want    int
+	}{
+		{size, 1},
+		{size + 33, 1},
+		{size + 34, 2},
+		{size + 34*251, 252},
+		// the 253rd input also grows the count from one to three bytes
+		{size + 34*252, 252},
+		{size + 34*252 + 1, 252},
+		{size + 34*252 + 2, 253},
+		{size + 34*1000 + 2, 1001},
+	}
+	for _, test := range tests {
+		if got := inputLimit(size, test.maxSize); got != test.want {
+			t.Errorf("inputLimit(%d, %d) = %d, want %d", size, test.maxSize, got, test.want)
+		}
+	}
+}
+
+// checkMerged checks that the transactions spend each of utxos once, in
+// order, and each stay within maxSize.
+func checkMerged(t *testing.T, signed []SignedTransaction, utxos []Utxo, maxSize int) {
+	t.Helper()
+	n := 0
+	for i, result := range signed {
+		size, err := result.Tx.Size()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size > maxSize {
+			t.Errorf("transaction %d: %d bytes, at most %d allowed", i, size, maxSize)
+		}
+		if len(result.Tx.outputs) != 1 {
+			t.Errorf("transaction %d: %d outputs", i, len(result.Tx.outputs))
+		}
+		for _, input := range result.Tx.inputs {
+			if n >= len(utxos) || input != NewTransactionInput(utxos[n].Hash, utxos[n].N) {
+				t.Fatalf("transaction %d: input %d out of order", i, n)
+			}
+			n++
+		}
+	}
+	if n != len(utxos) {
+		t.Errorf("%d of %d utxos spent", n, len(utxos))
+	}
+}
+
+func TestConsolidateUnderFreeSize(t *testing.T) {
+	utxos := testUtxos(100, D)
+	policy := DefaultFeePolicy()
+	signed, err := Consolidate(keyVectors[0].wif, NeoAssetHash, utxos, policy, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signed) < 2 {
+		t.Fatalf("%d transactions, the test needs more", len(signed))
+	}
+	checkMerged(t, signed, utxos, policy.FreeSize)
+	for i, result := range signed {
+		if fee := result.Tx.networkFee; fee.Sign() != 0 {
+			t.Errorf("transaction %d: network fee %s", i, fee)
+		}
+	}
+
+	// a last chunk of a single utxo is left alone
+	limit := len(signed[0].Tx.inputs)
+	signed, err = Consolidate(keyVectors[0].wif, NeoAssetHash, utxos[:limit+1], policy, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signed) != 1 {
+		t.Errorf("%d transactions for %d utxos", len(signed), limit+1)
+	}
+}
+
+func TestConsolidateVarIntGrowth(t *testing.T) {
+	utxos := testUtxos(300, D)
+	// the size of a merge of 253 utxos, the first count of three bytes
+	tb := NewTransactionBuilder(ContractTransaction)
+	for _, utxo := range utxos[:253] {
+		tb.AddUtxo(NeoAssetHash, utxo)
+	}
+	tx, err := tb.SetChangeAddress(keyVectors[0].address).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	size, err := tx.EstimateSize(SingleSigWitnessSize())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for maxSize, want := range map[int]int{size - 1: 252, size: 253, size + 33: 253, size + 34: 254} {
+		signed, err := Consolidate(keyVectors[0].wif, NeoAssetHash, utxos, nil, maxSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := len(signed[0].Tx.inputs); got != want {
+			t.Errorf("max size %d: %d inputs, want %d", maxSize, got, want)
+		}
+		checkMerged(t, signed, utxos, maxSize)
+	}
+}
+
+func TestConsolidateGasPaysFee(t *testing.T) {
+	utxos := testUtxos(60, D)
+	policy := DefaultFeePolicy()
+	signed, err := Consolidate(keyVectors[0].wif, GasAssetHash, utxos, policy, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMerged(t, signed, utxos, 102400)
+	for i, result := range signed {
+		size, _ := result.Tx.Size()
+		fee, _ := policy.MinimumFee(size)
+		if fee.Sign() == 0 || result.Tx.networkFee != fee {
+			t.Errorf("transaction %d of %d bytes: network fee %s, want %s", i, size, result.Tx.networkFee, fee)
+		}
+	}
+}
+
+func TestSweep(t *testing.T) {
+	neo := testUtxos(3, D)
+	gas := testUtxos(2, D)
+	for i := range gas {
+		gas[i].Hash[2] = 1
+	}
+	to := keyVectors[1].address
+	signed, err := Sweep(keyVectors[0].wif, to, map[UInt256][]Utxo{NeoAssetHash: neo, GasAssetHash: gas}, DefaultFeePolicy(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signed) != 2 {
+		t.Fatalf("%d transactions, want one per asset", len(signed))
+	}
+	toHash, _ := UInt160FromAddress(to)
+	for _, result := range signed {
+		output := result.Tx.outputs[0]
+		if output.scriptHash != toHash {
+			t.Errorf("asset %s swept to %s", output.assetId, output.scriptHash.Address())
+		}
+		want := map[UInt256][]Utxo{NeoAssetHash: neo, GasAssetHash: gas}[output.assetId]
+		checkMerged(t, []SignedTransaction{result}, want, 1024)
+	}
+}
+
+func TestClaimGasUnderFreeSize(t *testing.T) {
+	claims := make([]Claim, 100)
+	for i := range claims {
+		claims[i] = Claim{Hash: UInt256{byte(i)}, Value: Fixed8{value: D}}
+	}
+	signed, err := ClaimGas(keyVectors[0].wif, claims, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signed) < 2 {
+		t.Fatalf("%d transactions, the test needs more", len(signed))
+	}
+	total := 0
+	for i, result := range signed {
+		size, _ := result.Tx.Size()
+		if size > 1024 {
+			t.Errorf("transaction %d: %d bytes", i, size)
+		}
+		total += len(result.Tx.extdata.(*ClaimTransData).claims)
+	}
+	if total != len(claims) {
+		t.Errorf("%d of %d claims made", total, len(claims))
+	}
+	// the first transaction is full
+	if size, _ := signed[0].Tx.Size(); size+34 <= 1024 {
+		t.Errorf("first transaction of %d bytes has room for another claim", size)
+	}
+}
@@ -0,0 +1,290 @@
+package Neo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/neo-thinsdk-go/OpCode"
+	"github.com/neo-thinsdk-go/utils"
+)
+
+const (
+	ParameterTypeSignature        byte = 0x00
+	ParameterTypeBoolean          byte = 0x01
+	ParameterTypeInteger          byte = 0x02
+	ParameterTypeHash160          byte = 0x03
+	ParameterTypeHash256          byte = 0x04
+	ParameterTypeByteArray        byte = 0x05
+	ParameterTypePublicKey        byte = 0x06
+	ParameterTypeString           byte = 0x07
+	ParameterTypeArray            byte = 0x10
+	ParameterTypeMap              byte = 0x12
+	ParameterTypeInteropInterface byte = 0xf0
+	ParameterTypeVoid             byte = 0xff
+)
+
+var parameterTypeNames = map[byte]string{
+	ParameterTypeSignature:        "Signature",
+	ParameterTypeBoolean:          "Boolean",
+	ParameterTypeInteger:          "Integer",
+	ParameterTypeHash160:          "Hash160",
+	ParameterTypeHash256:          "Hash256",
+	ParameterTypeByteArray:        "ByteArray",
+	ParameterTypePublicKey:        "PublicKey",
+	ParameterTypeString:           "String",
+	ParameterTypeArray:            "Array",
+	ParameterTypeMap:              "Map",
+	ParameterTypeInteropInterface: "InteropInterface",
+	ParameterTypeVoid:             "Void",
+}
+
+// ContractParameter is a typed argument of a contract call, or a typed
+// value in the results of invokefunction and invokescript.
+//
+// Value holds []byte for Signature, ByteArray and PublicKey, bool for
+// Boolean, *big.Int for Integer, UInt160 for Hash160, UInt256 for Hash256,
+// string for String, []ContractParameter for Array, []ParameterPair for
+// Map and nil otherwise.
+type ContractParameter struct {
+	paramType byte
+	value     interface{}
+}
+
+// ParameterPair is one entry of a Map parameter.
+type ParameterPair struct {
+	Key   ContractParameter `json:"key"`
+	Value ContractParameter `json:"value"`
+}
+
+func NewSignatureParameter(signature []byte) (ContractParameter, error) {
+	if len(signature) != 64 {
+		return ContractParameter{}, fmt.Errorf("%w: signature of %d bytes", ErrBadParam, len(signature))
+	}
+	return ContractParameter{ParameterTypeSignature, append([]byte(nil), signature...)}, nil
+}
+
+func NewBooleanParameter(value bool) ContractParameter {
+	return ContractParameter{ParameterTypeBoolean, value}
+}
+
+func NewIntegerParameter(value *big.Int) ContractParameter {
+	return ContractParameter{ParameterTypeInteger, new(big.Int).Set(value)}
+}
+
+func NewInt64Parameter(value int64) ContractParameter {
+	return ContractParameter{ParameterTypeInteger, big.NewInt(value)}
+}
+
+func NewHash160Parameter(hash UInt160) ContractParameter {
+	return ContractParameter{ParameterTypeHash160, hash}
+}
+
+func NewHash256Parameter(hash UInt256) ContractParameter {
+	return ContractParameter{ParameterTypeHash256, hash}
+}
+
+func NewByteArrayParameter(data []byte) ContractParameter {
+	return ContractParameter{ParameterTypeByteArray, append([]byte(nil), data...)}
+}
+
+// NewPublicKeyParameter takes an encoded public key and keeps it
+// compressed.
+func NewPublicKeyParameter(publicKey []byte) (ContractParameter, error) {
+	key, err := compressPublicKey(publicKey)
+	if err != nil {
+		return ContractParameter{}, err
+	}
+	return ContractParameter{ParameterTypePublicKey, append([]byte(nil), key...)}, nil
+}
+
+func NewStringParameter(value string) ContractParameter {
+	return ContractParameter{ParameterTypeString, value}
+}
+
+func NewArrayParameter(items ...ContractParameter) ContractParameter {
+	return ContractParameter{ParameterTypeArray, append([]ContractParameter{}, items...)}
+}
+
+func NewMapParameter(pairs ...ParameterPair) ContractParameter {
+	return ContractParameter{ParameterTypeMap, append([]ParameterPair{}, pairs...)}
+}
+
+func NewVoidParameter() ContractParameter {
+	return ContractParameter{ParameterTypeVoid, nil}
+}
+
+func (self ContractParameter) Type() byte {
+	return self.paramType
+}
+
+func (self ContractParameter) Value() interface{} {
+	return self.value
+}
+
+// EmitParameter pushes param as NeoVM expects it: an Array is pushed as
+// its items in reverse order, their count and PACK, and a Map as NEWMAP
+// followed by DUP, key, value and SETITEM for every pair.
+func (sb *ScriptBuilder) EmitParameter(param ContractParameter) error {
+	switch value := param.value.(type) {
+	case []byte:
+		sb.EmitPushBytes(value)
+	case bool:
+		sb.EmitPushBool(value)
+	case *big.Int:
+		sb.EmitPushNumber(*value)
+	case UInt160:
+		sb.EmitPushBytes(value.Bytes())
+	case UInt256:
+		sb.EmitPushBytes(value.Bytes())
+	case string:
+		sb.EmitPushString(value)
+	case []ContractParameter:
+		for i := len(value) - 1; i >= 0; i-- {
+			if err := sb.EmitParameter(value[i]); err != nil {
+				return err
+			}
+		}
+		sb.EmitPushNumber(*big.NewInt(int64(len(value))))
+		sb.Emit(OpCode.PACK, []byte{})
+	case []ParameterPair:
+		sb.Emit(OpCode.NEWMAP, []byte{})
+		for _, pair := range value {
+			sb.Emit(OpCode.DUP, []byte{})
+			if err := sb.EmitParameter(pair.Key); err != nil {
+				return err
+			}
+			if err := sb.EmitParameter(pair.Value); err != nil {
+				return err
+			}
+			sb.Emit(OpCode.SETITEM, []byte{})
+		}
+	default:
+		return fmt.Errorf("%w: %s parameter cannot be pushed", ErrBadParam, nameOf(parameterTypeNames, param.paramType))
+	}
+	return nil
+}
+
+type contractParameterJSON struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// MarshalJSON encodes the parameter as invokefunction takes it: hashes as
+// 0x prefixed big-endian hex, byte arrays and keys as hex and integers as
+// decimal strings.
+func (self ContractParameter) MarshalJSON() ([]byte, error) {
+	var value interface{}
+	switch v := self.value.(type) {
+	case []byte:
+		value = utils.ToHexString(v)
+	case *big.Int:
+		value = v.String()
+	case nil:
+	default:
+		value = v
+	}
+	aux := contractParameterJSON{Type: nameOf(parameterTypeNames, self.paramType)}
+	if value != nil {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		aux.Value = data
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON accepts the output of MarshalJSON and, like neo-cli,
+// booleans and integers given as strings or as JSON literals.
+func (self *ContractParameter) UnmarshalJSON(data []byte) error {
+	var aux contractParameterJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	paramType, ok := valueOf(parameterTypeNames, aux.Type)
+	if !ok {
+		return fmt.Errorf("%w: unknown parameter type %q", ErrBadParam, aux.Type)
+	}
+	param, err := parseParameterValue(paramType, aux.Value)
+	if err != nil {
+		return err
+	}
+	*self = param
+	return nil
+}
+
+func parseParameterValue(paramType byte, raw json.RawMessage) (ContractParameter, error) {
+	switch paramType {
+	case ParameterTypeArray:
+		var items []ContractParameter
+		if len(raw) != 0 {
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return ContractParameter{}, err
+			}
+		}
+		return NewArrayParameter(items...), nil
+	case ParameterTypeMap:
+		var pairs []ParameterPair
+		if len(raw) != 0 {
+			if err := json.Unmarshal(raw, &pairs); err != nil {
+				return ContractParameter{}, err
+			}
+		}
+		return NewMapParameter(pairs...), nil
+	case ParameterTypeInteropInterface, ParameterTypeVoid:
+		return ContractParameter{paramType, nil}, nil
+	}
+
+	// the remaining types are given as strings, except that booleans and
+	// integers may also be JSON literals
+	var str string
+	if err := json.Unmarshal(raw, &str); err != nil {
+		if paramType != ParameterTypeBoolean && paramType != ParameterTypeInteger {
+			return ContractParameter{}, fmt.Errorf("%w: %s value %s", ErrBadParam, nameOf(parameterTypeNames, paramType), raw)
+		}
+		str = string(bytes.TrimSpace(raw))
+	}
+
+	switch paramType {
+	case ParameterTypeSignature, ParameterTypeByteArray, ParameterTypePublicKey:
+		data, err := parseHex(str)
+		if err != nil {
+			return ContractParameter{}, fmt.Errorf("%w: %v", ErrBadParam, err)
+		}
+		switch paramType {
+		case ParameterTypeSignature:
+			return NewSignatureParameter(data)
+		case ParameterTypePublicKey:
+			return NewPublicKeyParameter(data)
+		}
+		return NewByteArrayParameter(data), nil
+	case ParameterTypeBoolean:
+		switch str {
+		case "true", "True":
+			return NewBooleanParameter(true), nil
+		case "false", "False":
+			return NewBooleanParameter(false), nil
+		}
+	case ParameterTypeInteger:
+		if value, ok := new(big.Int).SetString(str, 10); ok {
+			return NewIntegerParameter(value), nil
+		}
+	case ParameterTypeHash160:
+		hash, err := ParseUInt160(str)
+		if err != nil {
+			return ContractParameter{}, err
+		}
+		return NewHash160Parameter(hash), nil
+	case ParameterTypeHash256:
+		hash, err := ParseUInt256(str)
+		if err != nil {
+			return ContractParameter{}, err
+		}
+		return NewHash256Parameter(hash), nil
+	case ParameterTypeString:
+		return NewStringParameter(str), nil
+	}
+	return ContractParameter{}, fmt.Errorf("%w: %s value %q", ErrBadParam, nameOf(parameterTypeNames, paramType), str)
+}
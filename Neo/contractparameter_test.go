@@ -0,0 +1,136 @@
+package Neo
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/neo-thinsdk-go/utils"
+)
+
+func mustParameter(param ContractParameter, err error) ContractParameter {
+	if err != nil {
+		panic(err)
+	}
+	return param
+}
+
+func TestContractParameterJSON(t *testing.T) {
+	signature := strings.Repeat("01", 64)
+	signatureBytes, _ := utils.ToBytes(signature)
+	publicKey, _ := utils.ToBytes(keyVectors[0].publicKey)
+	rpx, _ := ParseUInt160(rpxScriptHash)
+
+	tests := []struct {
+		name   string
+		param  ContractParameter
+		json   string
+		script string
+	}{
+		{"signature", mustParameter(NewSignatureParameter(signatureBytes)),
+			`{"type":"Signature","value":"` + signature + `"}`, "40" + signature},
+		{"boolean", NewBooleanParameter(true),
+			`{"type":"Boolean","value":true}`, "51"},
+		{"integer", NewInt64Parameter(100000000),
+			`{"type":"Integer","value":"100000000"}`, "0400e1f505"},
+		{"negative integer", NewIntegerParameter(big.NewInt(-1)),
+			`{"type":"Integer","value":"-1"}`, "4f"},
+		{"hash160", NewHash160Parameter(rpx),
+			`{"type":"Hash160","value":"` + rpxScriptHash + `"}`, "14f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec"},
+		{"hash256", NewHash256Parameter(NeoAssetHash),
+			`{"type":"Hash256","value":"0x` + NeoAssetId + `"}`, "209b7cffdaa674beae0f930ebe6085af9093e5fe56b34a5c220ccdcf6efc336fc5"},
+		{"byte array", NewByteArrayParameter([]byte{1, 2}),
+			`{"type":"ByteArray","value":"0102"}`, "020102"},
+		{"public key", mustParameter(NewPublicKeyParameter(publicKey)),
+			`{"type":"PublicKey","value":"` + keyVectors[0].publicKey + `"}`, "21" + keyVectors[0].publicKey},
+		{"string", NewStringParameter("transfer"),
+			`{"type":"String","value":"transfer"}`, "087472616e73666572"},
+		{"empty array", NewArrayParameter(),
+			`{"type":"Array","value":[]}`, "00c1"},
+		{"nested array", NewArrayParameter(NewInt64Parameter(1), NewArrayParameter(NewStringParameter("a"), NewBooleanParameter(false))),
+			`{"type":"Array","value":[{"type":"Integer","value":"1"},{"type":"Array","value":[{"type":"String","value":"a"},{"type":"Boolean","value":false}]}]}`,
+			"00" + "0161" + "52c1" + "51" + "52c1"},
+		{"nested map", NewMapParameter(
+			ParameterPair{NewStringParameter("a"), NewInt64Parameter(1)},
+			ParameterPair{NewInt64Parameter(2), NewArrayParameter(NewBooleanParameter(true))}),
+			`{"type":"Map","value":[{"key":{"type":"String","value":"a"},"value":{"type":"Integer","value":"1"}},` +
+				`{"key":{"type":"Integer","value":"2"},"value":{"type":"Array","value":[{"type":"Boolean","value":true}]}}]}`,
+			"c7" + "76" + "0161" + "51" + "c4" + "76" + "52" + "5151c1" + "c4"},
+		{"void", NewVoidParameter(), `{"type":"Void"}`, ""},
+		{"interop interface", ContractParameter{ParameterTypeInteropInterface, nil}, `{"type":"InteropInterface"}`, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.param)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.json {
+				t.Errorf("json %s, want %s", data, test.json)
+			}
+			var decoded ContractParameter
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			again, _ := json.Marshal(decoded)
+			if string(again) != test.json {
+				t.Errorf("after a round trip %s", again)
+			}
+
+			sb := &ScriptBuilder{}
+			err = sb.EmitParameter(decoded)
+			if test.script == "" {
+				if !errors.Is(err, ErrBadParam) {
+					t.Errorf("EmitParameter: got %v, want ErrBadParam", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			script, _ := sb.ToArray()
+			if got := utils.ToHexString(script); got != test.script {
+				t.Errorf("script %s, want %s", got, test.script)
+			}
+		})
+	}
+}
+
+func TestContractParameterUnmarshal(t *testing.T) {
+	// neo-cli also takes booleans and integers as literals or strings
+	for input, want := range map[string]string{
+		`{"type":"Integer","value":5}`:                       `{"type":"Integer","value":"5"}`,
+		`{"type":"Boolean","value":"true"}`:                  `{"type":"Boolean","value":true}`,
+		`{"type":"Array"}`:                                   `{"type":"Array","value":[]}`,
+		`{"type":"Map"}`:                                     `{"type":"Map","value":[]}`,
+		`{"type":"ByteArray","value":"0x0102"}`:              `{"type":"ByteArray","value":"0102"}`,
+		`{"type":"Hash160","value":"` + rpxScriptHash + `"}`: `{"type":"Hash160","value":"` + rpxScriptHash + `"}`,
+	} {
+		var param ContractParameter
+		if err := json.Unmarshal([]byte(input), &param); err != nil {
+			t.Errorf("%s: %v", input, err)
+			continue
+		}
+		if data, _ := json.Marshal(param); string(data) != want {
+			t.Errorf("%s: decoded as %s, want %s", input, data, want)
+		}
+	}
+
+	for _, input := range []string{
+		`{"type":"Nope","value":"1"}`,
+		`{"type":"Integer","value":"1.5"}`,
+		`{"type":"Boolean","value":"yes"}`,
+		`{"type":"Signature","value":"0102"}`,
+		`{"type":"ByteArray","value":"xyz"}`,
+		`{"type":"PublicKey","value":"0102"}`,
+		`{"type":"Hash160","value":"0x01"}`,
+		`{"type":"Map","value":[{"key":{"type":"Nope"},"value":{"type":"Void"}}]}`,
+	} {
+		var param ContractParameter
+		if err := json.Unmarshal([]byte(input), &param); err == nil {
+			t.Errorf("%s: no error", input)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package Neo
+
+import "errors"
+
+// Errors returned by serialization, signing and script building. They are
+// usually wrapped with more context, so compare them with errors.Is.
+var (
+	ErrUnknownTxType     = errors.New("unknown transaction type")
+	ErrMissingExtData    = errors.New("transaction extdata missing")
+	ErrBadAttribute      = errors.New("bad transaction attribute")
+	ErrTruncatedInput    = errors.New("truncated input")
+	ErrBadFormat         = errors.New("bad transaction format")
+	ErrBadPublicKey      = errors.New("bad public key")
+	ErrBadSignature      = errors.New("signature verification failed")
+	ErrAddressMismatch   = errors.New("address does not match public key")
+	ErrBadScriptHash     = errors.New("bad script hash")
+	ErrBadHash           = errors.New("bad hash")
+	ErrBadAddress        = errors.New("bad address")
+	ErrBadOpCode         = errors.New("bad opcode")
+	ErrBadSysCall        = errors.New("bad syscall name")
+	ErrBadLabel          = errors.New("bad script label")
+	ErrBadParam          = errors.New("bad contract parameter")
+	ErrBadAmount         = errors.New("bad amount")
+	ErrFixed8Overflow    = errors.New("fixed8 overflow")
+	ErrNoReference       = errors.New("referenced output not found")
+	ErrUnknownAsset      = errors.New("unknown asset")
+	ErrNeedsVM           = errors.New("witness needs a VM to verify")
+	ErrMissingWitness    = errors.New("required witness missing")
+	ErrExtraWitness      = errors.New("witness not required")
+	ErrWitnessMismatch   = errors.New("witness does not match required script hash")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrTooManyInputs     = errors.New("too many inputs")
+)
@@ -0,0 +1,229 @@
+package Neo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// D is the number of Fixed8 units in one whole token.
+const D = 100000000
+
+// Fixed8 is a signed fixed point amount with 8 decimals, stored as the
+// number of 0.00000001 units like the reference implementation.
+type Fixed8 struct {
+	value int64
+}
+
+var (
+	Fixed8Zero     = Fixed8{}
+	Fixed8Satoshi  = Fixed8{value: 1}
+	Fixed8MaxValue = Fixed8{value: math.MaxInt64}
+	Fixed8MinValue = Fixed8{value: math.MinInt64}
+)
+
+// Fixed8FromRaw returns the amount of raw 0.00000001 units.
+func Fixed8FromRaw(raw int64) Fixed8 {
+	return Fixed8{value: raw}
+}
+
+// Fixed8FromInt64 returns the amount of whole tokens.
+func Fixed8FromInt64(units int64) (Fixed8, error) {
+	if units > math.MaxInt64/D || units < math.MinInt64/D {
+		return Fixed8{}, fmt.Errorf("%w: %d units", ErrFixed8Overflow, units)
+	}
+	return Fixed8{value: units * D}, nil
+}
+
+// ParseFixed8 parses a decimal string such as "12.345", "-0.1" or ".5"
+// with at most 8 decimals.
+func ParseFixed8(str string) (Fixed8, error) {
+	digits := str
+	negative := false
+	if strings.HasPrefix(digits, "-") {
+		negative = true
+		digits = digits[1:]
+	} else if strings.HasPrefix(digits, "+") {
+		digits = digits[1:]
+	}
+	parts := strings.SplitN(digits, ".", 2)
+	var intPart uint64
+	var err error
+	if parts[0] != "" || len(parts) == 1 {
+		if intPart, err = strconv.ParseUint(parts[0], 10, 64); err != nil {
+			return Fixed8{}, fmt.Errorf("%w: %q", ErrBadAmount, str)
+		}
+	}
+	var fracPart uint64
+	if len(parts) == 2 {
+		frac := parts[1]
+		if len(frac) == 0 || len(frac) > 8 {
+			return Fixed8{}, fmt.Errorf("%w: %q", ErrBadAmount, str)
+		}
+		fracPart, err = strconv.ParseUint(frac+strings.Repeat("0", 8-len(frac)), 10, 64)
+		if err != nil {
+			return Fixed8{}, fmt.Errorf("%w: %q", ErrBadAmount, str)
+		}
+	}
+
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+	if intPart > (limit-fracPart)/D {
+		return Fixed8{}, fmt.Errorf("%w: %q", ErrFixed8Overflow, str)
+	}
+	value := intPart*D + fracPart
+	if negative {
+		return Fixed8{value: int64(-value)}, nil
+	}
+	return Fixed8{value: int64(value)}, nil
+}
+
+// Raw returns the amount in 0.00000001 units.
+func (f Fixed8) Raw() int64 {
+	return f.value
+}
+
+// IntegerValue returns the whole tokens of the amount, truncated toward
+// zero.
+func (f Fixed8) IntegerValue() int64 {
+	return f.value / D
+}
+
+// String formats the amount as a decimal number without trailing zeros,
+// like neo-cli does.
+func (f Fixed8) String() string {
+	sign := ""
+	abs := uint64(f.value)
+	if f.value < 0 {
+		sign = "-"
+		abs = uint64(-f.value)
+	}
+	str := strconv.FormatUint(abs/D, 10)
+	if frac := abs % D; frac != 0 {
+		str += "." + strings.TrimRight(fmt.Sprintf("%08d", frac), "0")
+	}
+	return sign + str
+}
+
+func (f Fixed8) Add(other Fixed8) (Fixed8, error) {
+	sum := f.value + other.value
+	if (other.value > 0 && sum < f.value) || (other.value < 0 && sum > f.value) {
+		return Fixed8{}, fmt.Errorf("%w: %s + %s", ErrFixed8Overflow, f, other)
+	}
+	return Fixed8{value: sum}, nil
+}
+
+func (f Fixed8) Sub(other Fixed8) (Fixed8, error) {
+	diff := f.value - other.value
+	if (other.value < 0 && diff < f.value) || (other.value > 0 && diff > f.value) {
+		return Fixed8{}, fmt.Errorf("%w: %s - %s", ErrFixed8Overflow, f, other)
+	}
+	return Fixed8{value: diff}, nil
+}
+
+// Mul multiplies two amounts, truncating digits beyond the 8th decimal.
+func (f Fixed8) Mul(other Fixed8) (Fixed8, error) {
+	product := new(big.Int).Mul(big.NewInt(f.value), big.NewInt(other.value))
+	product.Quo(product, big.NewInt(D))
+	if !product.IsInt64() {
+		return Fixed8{}, fmt.Errorf("%w: %s * %s", ErrFixed8Overflow, f, other)
+	}
+	return Fixed8{value: product.Int64()}, nil
+}
+
+// MulInt multiplies the amount by a plain integer.
+func (f Fixed8) MulInt(n int64) (Fixed8, error) {
+	product := new(big.Int).Mul(big.NewInt(f.value), big.NewInt(n))
+	if !product.IsInt64() {
+		return Fixed8{}, fmt.Errorf("%w: %s * %d", ErrFixed8Overflow, f, n)
+	}
+	return Fixed8{value: product.Int64()}, nil
+}
+
+// Neg fails only for the smallest amount, whose negation does not fit.
+func (f Fixed8) Neg() (Fixed8, error) {
+	if f.value == math.MinInt64 {
+		return Fixed8{}, fmt.Errorf("%w: -(%s)", ErrFixed8Overflow, f)
+	}
+	return Fixed8{value: -f.value}, nil
+}
+
+func (f Fixed8) Abs() (Fixed8, error) {
+	if f.value < 0 {
+		return f.Neg()
+	}
+	return f, nil
+}
+
+// Ceiling rounds the amount up to whole tokens.
+func (f Fixed8) Ceiling() (Fixed8, error) {
+	remainder := f.value % D
+	if remainder > 0 {
+		if f.value-remainder > math.MaxInt64-D {
+			return Fixed8{}, fmt.Errorf("%w: ceiling of %s", ErrFixed8Overflow, f)
+		}
+		return Fixed8{value: f.value - remainder + D}, nil
+	}
+	return Fixed8{value: f.value - remainder}, nil
+}
+
+// Cmp returns -1, 0 or +1 when f is less than, equal to or greater than
+// other.
+func (f Fixed8) Cmp(other Fixed8) int {
+	if f.value < other.value {
+		return -1
+	}
+	if f.value > other.value {
+		return 1
+	}
+	return 0
+}
+
+func (f Fixed8) Equal(other Fixed8) bool {
+	return f.value == other.value
+}
+
+func (f Fixed8) LessThan(other Fixed8) bool {
+	return f.value < other.value
+}
+
+func (f Fixed8) GreaterThan(other Fixed8) bool {
+	return f.value > other.value
+}
+
+func (f Fixed8) IsZero() bool {
+	return f.value == 0
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of the amount.
+func (f Fixed8) Sign() int {
+	return f.Cmp(Fixed8Zero)
+}
+
+// MarshalJSON encodes the amount as a decimal string, as neo-cli does.
+func (f Fixed8) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+// UnmarshalJSON accepts a decimal string or a JSON number.
+func (f *Fixed8) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("%w: %s", ErrBadAmount, data)
+		}
+		str = number.String()
+	}
+	value, err := ParseFixed8(str)
+	if err != nil {
+		return err
+	}
+	*f = value
+	return nil
+}
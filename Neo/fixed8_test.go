@@ -0,0 +1,135 @@
+package Neo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseFixed8(t *testing.T) {
+	tests := []struct {
+		str  string
+		raw  int64
+		err  error
+		text string
+	}{
+		{"0", 0, nil, "0"},
+		{"1", D, nil, "1"},
+		{"12.345", 1234500000, nil, "12.345"},
+		{"-0.1", -10000000, nil, "-0.1"},
+		{"+7", 7 * D, nil, "7"},
+		{".5", 50000000, nil, "0.5"},
+		{"-.5", -50000000, nil, "-0.5"},
+		{"0.00000001", 1, nil, "0.00000001"},
+		{"1.12345678", 112345678, nil, "1.12345678"},
+		{"92233720368.54775807", math.MaxInt64, nil, "92233720368.54775807"},
+		{"-92233720368.54775808", math.MinInt64, nil, "-92233720368.54775808"},
+		{"92233720368.54775808", 0, ErrFixed8Overflow, ""},
+		{"-92233720368.54775809", 0, ErrFixed8Overflow, ""},
+		{"92233720369", 0, ErrFixed8Overflow, ""},
+		{"1.123456789", 0, ErrBadAmount, ""},
+		{"0.000000001", 0, ErrBadAmount, ""},
+		{"", 0, ErrBadAmount, ""},
+		{".", 0, ErrBadAmount, ""},
+		{"1.", 0, ErrBadAmount, ""},
+		{"-", 0, ErrBadAmount, ""},
+		{"--1", 0, ErrBadAmount, ""},
+		{"1.-5", 0, ErrBadAmount, ""},
+		{"1e8", 0, ErrBadAmount, ""},
+		{" 1", 0, ErrBadAmount, ""},
+	}
+	for _, test := range tests {
+		f, err := ParseFixed8(test.str)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("ParseFixed8(%q): got %v, want %v", test.str, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseFixed8(%q): %v", test.str, err)
+			continue
+		}
+		if f.Raw() != test.raw {
+			t.Errorf("ParseFixed8(%q) = %d, want %d", test.str, f.Raw(), test.raw)
+		}
+		if f.String() != test.text {
+			t.Errorf("ParseFixed8(%q).String() = %s, want %s", test.str, f, test.text)
+		}
+	}
+}
+
+func TestFixed8Arithmetic(t *testing.T) {
+	max := Fixed8FromRaw(math.MaxInt64)
+	min := Fixed8FromRaw(math.MinInt64)
+	one := Fixed8FromRaw(D)
+	half := Fixed8FromRaw(D / 2)
+	check := func(name string, got Fixed8, err error, want int64) {
+		t.Helper()
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		} else if got.Raw() != want {
+			t.Errorf("%s = %d, want %d", name, got.Raw(), want)
+		}
+	}
+	overflows := func(name string, _ Fixed8, err error) {
+		t.Helper()
+		if !errors.Is(err, ErrFixed8Overflow) {
+			t.Errorf("%s: got %v, want ErrFixed8Overflow", name, err)
+		}
+	}
+
+	sum, err := max.Add(Fixed8FromRaw(-1))
+	check("max + -1", sum, err, math.MaxInt64-1)
+	sum, err = max.Add(Fixed8FromRaw(1))
+	overflows("max + 1", sum, err)
+	sum, err = min.Add(Fixed8FromRaw(-1))
+	overflows("min + -1", sum, err)
+	diff, err := min.Sub(Fixed8FromRaw(1))
+	overflows("min - 1", diff, err)
+	diff, err = Fixed8Zero.Sub(min)
+	overflows("0 - min", diff, err)
+
+	product, err := half.Mul(Fixed8FromRaw(3 * D))
+	check("0.5 * 3", product, err, 3*D/2)
+	product, err = Fixed8FromRaw(1).Mul(half)
+	check("0.00000001 * 0.5", product, err, 0)
+	product, err = max.Mul(one)
+	check("max * 1", product, err, math.MaxInt64)
+	product, err = max.Mul(Fixed8FromRaw(2 * D))
+	overflows("max * 2", product, err)
+	product, err = min.Mul(Fixed8FromRaw(-D))
+	overflows("min * -1", product, err)
+
+	product, err = one.MulInt(-3)
+	check("1 * -3", product, err, -3*D)
+	product, err = max.MulInt(2)
+	overflows("max *int 2", product, err)
+	product, err = min.MulInt(-1)
+	overflows("min *int -1", product, err)
+
+	neg, err := one.Neg()
+	check("-1", neg, err, -D)
+	neg, err = max.Neg()
+	check("-max", neg, err, -math.MaxInt64)
+	neg, err = min.Neg()
+	overflows("-min", neg, err)
+
+	abs, err := Fixed8FromRaw(-D).Abs()
+	check("abs -1", abs, err, D)
+	abs, err = max.Abs()
+	check("abs max", abs, err, math.MaxInt64)
+	abs, err = min.Abs()
+	overflows("abs min", abs, err)
+
+	ceiling, err := Fixed8FromRaw(D + 1).Ceiling()
+	check("ceiling 1.00000001", ceiling, err, 2*D)
+	ceiling, err = Fixed8FromRaw(-D - 1).Ceiling()
+	check("ceiling -1.00000001", ceiling, err, -D)
+	ceiling, err = Fixed8FromRaw(3 * D).Ceiling()
+	check("ceiling 3", ceiling, err, 3*D)
+	ceiling, err = min.Ceiling()
+	check("ceiling min", ceiling, err, math.MinInt64+54775808)
+	ceiling, err = max.Ceiling()
+	overflows("ceiling max", ceiling, err)
+}
@@ -0,0 +1,262 @@
+package Neo
+
+import (
+	"fmt"
+	"sort"
+)
+
+// InteropService describes a syscall of the Neo 2 interop layer. Parameters
+// lists what the service pops from the stack, first popped first, and
+// Returns what it pushes back. Shapes are parameter type names, "Any" for
+// any stack item, "Void" for nothing, or the name of the VM object, such
+// as "StorageContext" or "Transaction".
+type InteropService struct {
+	Name       string
+	Parameters []string
+	Returns    string
+}
+
+var interopServices = make(map[string]InteropService)
+
+func registerInterop(name, returns string, parameters ...string) {
+	interopServices[name] = InteropService{Name: name, Parameters: parameters, Returns: returns}
+}
+
+func init() {
+	// System services, shared with later versions of the protocol
+	registerInterop("System.ExecutionEngine.GetScriptContainer", "ScriptContainer")
+	registerInterop("System.ExecutionEngine.GetExecutingScriptHash", "ByteArray")
+	registerInterop("System.ExecutionEngine.GetCallingScriptHash", "ByteArray")
+	registerInterop("System.ExecutionEngine.GetEntryScriptHash", "ByteArray")
+	registerInterop("System.Runtime.Platform", "ByteArray")
+	registerInterop("System.Runtime.GetTrigger", "Integer")
+	registerInterop("System.Runtime.CheckWitness", "Boolean", "ByteArray")
+	registerInterop("System.Runtime.Notify", "Void", "Any")
+	registerInterop("System.Runtime.Log", "Void", "String")
+	registerInterop("System.Runtime.GetTime", "Integer")
+	registerInterop("System.Runtime.Serialize", "ByteArray", "Any")
+	registerInterop("System.Runtime.Deserialize", "Any", "ByteArray")
+	registerInterop("System.Blockchain.GetHeight", "Integer")
+	registerInterop("System.Blockchain.GetHeader", "Header", "ByteArray")
+	registerInterop("System.Blockchain.GetBlock", "Block", "ByteArray")
+	registerInterop("System.Blockchain.GetTransaction", "Transaction", "Hash256")
+	registerInterop("System.Blockchain.GetTransactionHeight", "Integer", "Hash256")
+	registerInterop("System.Blockchain.GetContract", "Contract", "Hash160")
+	registerInterop("System.Header.GetIndex", "Integer", "Header")
+	registerInterop("System.Header.GetHash", "ByteArray", "Header")
+	registerInterop("System.Header.GetPrevHash", "ByteArray", "Header")
+	registerInterop("System.Header.GetTimestamp", "Integer", "Header")
+	registerInterop("System.Block.GetTransactionCount", "Integer", "Block")
+	registerInterop("System.Block.GetTransactions", "Array", "Block")
+	registerInterop("System.Block.GetTransaction", "Transaction", "Block", "Integer")
+	registerInterop("System.Transaction.GetHash", "ByteArray", "Transaction")
+	registerInterop("System.Contract.Destroy", "Void")
+	registerInterop("System.Contract.GetStorageContext", "StorageContext", "Contract")
+	registerInterop("System.Storage.GetContext", "StorageContext")
+	registerInterop("System.Storage.GetReadOnlyContext", "StorageContext")
+	registerInterop("System.Storage.Get", "ByteArray", "StorageContext", "ByteArray")
+	registerInterop("System.Storage.Put", "Void", "StorageContext", "ByteArray", "ByteArray")
+	registerInterop("System.Storage.PutEx", "Void", "StorageContext", "ByteArray", "ByteArray", "Integer")
+	registerInterop("System.Storage.Delete", "Void", "StorageContext", "ByteArray")
+	registerInterop("System.StorageContext.AsReadOnly", "StorageContext", "StorageContext")
+
+	// Neo services
+	registerInterop("Neo.Runtime.GetTrigger", "Integer")
+	registerInterop("Neo.Runtime.CheckWitness", "Boolean", "ByteArray")
+	registerInterop("Neo.Runtime.Notify", "Void", "Any")
+	registerInterop("Neo.Runtime.Log", "Void", "String")
+	registerInterop("Neo.Runtime.GetTime", "Integer")
+	registerInterop("Neo.Runtime.Serialize", "ByteArray", "Any")
+	registerInterop("Neo.Runtime.Deserialize", "Any", "ByteArray")
+	registerInterop("Neo.Blockchain.GetHeight", "Integer")
+	registerInterop("Neo.Blockchain.GetHeader", "Header", "ByteArray")
+	registerInterop("Neo.Blockchain.GetBlock", "Block", "ByteArray")
+	registerInterop("Neo.Blockchain.GetTransaction", "Transaction", "Hash256")
+	registerInterop("Neo.Blockchain.GetTransactionHeight", "Integer", "Hash256")
+	registerInterop("Neo.Blockchain.GetAccount", "Account", "Hash160")
+	registerInterop("Neo.Blockchain.GetValidators", "Array")
+	registerInterop("Neo.Blockchain.GetAsset", "Asset", "Hash256")
+	registerInterop("Neo.Blockchain.GetContract", "Contract", "Hash160")
+	registerInterop("Neo.Header.GetHash", "ByteArray", "Header")
+	registerInterop("Neo.Header.GetVersion", "Integer", "Header")
+	registerInterop("Neo.Header.GetPrevHash", "ByteArray", "Header")
+	registerInterop("Neo.Header.GetMerkleRoot", "ByteArray", "Header")
+	registerInterop("Neo.Header.GetTimestamp", "Integer", "Header")
+	registerInterop("Neo.Header.GetIndex", "Integer", "Header")
+	registerInterop("Neo.Header.GetConsensusData", "Integer", "Header")
+	registerInterop("Neo.Header.GetNextConsensus", "ByteArray", "Header")
+	registerInterop("Neo.Block.GetTransactionCount", "Integer", "Block")
+	registerInterop("Neo.Block.GetTransactions", "Array", "Block")
+	registerInterop("Neo.Block.GetTransaction", "Transaction", "Block", "Integer")
+	registerInterop("Neo.Transaction.GetHash", "ByteArray", "Transaction")
+	registerInterop("Neo.Transaction.GetType", "Integer", "Transaction")
+	registerInterop("Neo.Transaction.GetAttributes", "Array", "Transaction")
+	registerInterop("Neo.Transaction.GetInputs", "Array", "Transaction")
+	registerInterop("Neo.Transaction.GetOutputs", "Array", "Transaction")
+	registerInterop("Neo.Transaction.GetReferences", "Array", "Transaction")
+	registerInterop("Neo.Transaction.GetUnspentCoins", "Array", "Transaction")
+	registerInterop("Neo.Transaction.GetWitnesses", "Array", "Transaction")
+	registerInterop("Neo.InvocationTransaction.GetScript", "ByteArray", "Transaction")
+	registerInterop("Neo.Witness.GetVerificationScript", "ByteArray", "Witness")
+	registerInterop("Neo.Attribute.GetUsage", "Integer", "Attribute")
+	registerInterop("Neo.Attribute.GetData", "ByteArray", "Attribute")
+	registerInterop("Neo.Input.GetHash", "ByteArray", "Input")
+	registerInterop("Neo.Input.GetIndex", "Integer", "Input")
+	registerInterop("Neo.Output.GetAssetId", "ByteArray", "Output")
+	registerInterop("Neo.Output.GetValue", "Integer", "Output")
+	registerInterop("Neo.Output.GetScriptHash", "ByteArray", "Output")
+	registerInterop("Neo.Account.GetScriptHash", "ByteArray", "Account")
+	registerInterop("Neo.Account.GetVotes", "Array", "Account")
+	registerInterop("Neo.Account.GetBalance", "Integer", "Account", "Hash256")
+	registerInterop("Neo.Account.IsStandard", "Boolean", "Hash160")
+	registerInterop("Neo.Asset.Create", "Asset", "Integer", "String", "Integer", "Integer", "PublicKey", "Hash160", "Hash160")
+	registerInterop("Neo.Asset.Renew", "Integer", "Asset", "Integer")
+	registerInterop("Neo.Asset.GetAssetId", "ByteArray", "Asset")
+	registerInterop("Neo.Asset.GetAssetType", "Integer", "Asset")
+	registerInterop("Neo.Asset.GetAmount", "Integer", "Asset")
+	registerInterop("Neo.Asset.GetAvailable", "Integer", "Asset")
+	registerInterop("Neo.Asset.GetPrecision", "Integer", "Asset")
+	registerInterop("Neo.Asset.GetOwner", "ByteArray", "Asset")
+	registerInterop("Neo.Asset.GetAdmin", "ByteArray", "Asset")
+	registerInterop("Neo.Asset.GetIssuer", "ByteArray", "Asset")
+	registerInterop("Neo.Contract.Create", "Contract", "ByteArray", "ByteArray", "Integer", "Integer", "String", "String", "String", "String", "String")
+	registerInterop("Neo.Contract.Migrate", "Contract", "ByteArray", "ByteArray", "Integer", "Integer", "String", "String", "String", "String", "String")
+	registerInterop("Neo.Contract.GetScript", "ByteArray", "Contract")
+	registerInterop("Neo.Contract.IsPayable", "Boolean", "Contract")
+	registerInterop("Neo.Contract.GetStorageContext", "StorageContext", "Contract")
+	registerInterop("Neo.Contract.Destroy", "Void")
+	registerInterop("Neo.Storage.GetContext", "StorageContext")
+	registerInterop("Neo.Storage.GetReadOnlyContext", "StorageContext")
+	registerInterop("Neo.Storage.Get", "ByteArray", "StorageContext", "ByteArray")
+	registerInterop("Neo.Storage.Put", "Void", "StorageContext", "ByteArray", "ByteArray")
+	registerInterop("Neo.Storage.Delete", "Void", "StorageContext", "ByteArray")
+	registerInterop("Neo.Storage.Find", "Iterator", "StorageContext", "ByteArray")
+	registerInterop("Neo.StorageContext.AsReadOnly", "StorageContext", "StorageContext")
+	registerInterop("Neo.Enumerator.Create", "Enumerator", "Array")
+	registerInterop("Neo.Enumerator.Next", "Boolean", "Enumerator")
+	registerInterop("Neo.Enumerator.Value", "Any", "Enumerator")
+	registerInterop("Neo.Enumerator.Concat", "Enumerator", "Enumerator", "Enumerator")
+	registerInterop("Neo.Iterator.Create", "Iterator", "Any")
+	registerInterop("Neo.Iterator.Key", "Any", "Iterator")
+	registerInterop("Neo.Iterator.Keys", "Enumerator", "Iterator")
+	registerInterop("Neo.Iterator.Values", "Enumerator", "Iterator")
+	registerInterop("Neo.Iterator.Next", "Boolean", "Iterator")
+	registerInterop("Neo.Iterator.Value", "Any", "Iterator")
+	registerInterop("Neo.Iterator.Concat", "Iterator", "Iterator", "Iterator")
+}
+
+// LookupInteropService returns the description of the syscall name.
+func LookupInteropService(name string) (InteropService, bool) {
+	service, ok := interopServices[name]
+	if !ok {
+		return InteropService{}, false
+	}
+	service.Parameters = append([]string(nil), service.Parameters...)
+	return service, true
+}
+
+// InteropServiceNames returns the names of all known syscalls, sorted.
+func InteropServiceNames() []string {
+	names := make([]string, 0, len(interopServices))
+	for name := range interopServices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateSysCalls makes EmitSysCall reject names that are not known
+// interop services, instead of leaving the mistake to the node.
+func (sb *ScriptBuilder) ValidateSysCalls(enable bool) {
+	sb.validateSysCalls = enable
+}
+
+// EmitInteropCall pushes args, which take the values of EmitParamJson
+// lists, so that the first one is popped first and calls the service
+// name. The name and the number of args are always checked.
+func (sb *ScriptBuilder) EmitInteropCall(name string, args ...interface{}) error {
+	service, ok := interopServices[name]
+	if !ok {
+		return fmt.Errorf("%w: unknown interop service %q", ErrBadSysCall, name)
+	}
+	if len(args) != len(service.Parameters) {
+		return fmt.Errorf("%w: %s takes %d arguments, got %d", ErrBadParam, name, len(service.Parameters), len(args))
+	}
+	for i := len(args) - 1; i >= 0; i-- {
+		if err := sb.pushParam(args[i]); err != nil {
+			return err
+		}
+	}
+	return sb.EmitSysCall(name)
+}
+
+// EmitCheckWitness pushes whether the transaction is witnessed by
+// scriptHash.
+func (sb *ScriptBuilder) EmitCheckWitness(scriptHash UInt160) error {
+	sb.EmitPushBytes(scriptHash.Bytes())
+	return sb.EmitSysCall("Neo.Runtime.CheckWitness")
+}
+
+// EmitNotify sends args as a notification of the running contract.
+func (sb *ScriptBuilder) EmitNotify(args ...interface{}) error {
+	if err := sb.pushList(args); err != nil {
+		return err
+	}
+	return sb.EmitSysCall("Neo.Runtime.Notify")
+}
+
+func (sb *ScriptBuilder) EmitLog(message string) error {
+	sb.EmitPushString(message)
+	return sb.EmitSysCall("Neo.Runtime.Log")
+}
+
+// EmitStorageGet pushes the value stored under key by the running
+// contract.
+func (sb *ScriptBuilder) EmitStorageGet(key []byte) error {
+	sb.EmitPushBytes(key)
+	if err := sb.EmitSysCall("Neo.Storage.GetContext"); err != nil {
+		return err
+	}
+	return sb.EmitSysCall("Neo.Storage.Get")
+}
+
+func (sb *ScriptBuilder) EmitStoragePut(key, value []byte) error {
+	sb.EmitPushBytes(value)
+	sb.EmitPushBytes(key)
+	if err := sb.EmitSysCall("Neo.Storage.GetContext"); err != nil {
+		return err
+	}
+	return sb.EmitSysCall("Neo.Storage.Put")
+}
+
+func (sb *ScriptBuilder) EmitStorageDelete(key []byte) error {
+	sb.EmitPushBytes(key)
+	if err := sb.EmitSysCall("Neo.Storage.GetContext"); err != nil {
+		return err
+	}
+	return sb.EmitSysCall("Neo.Storage.Delete")
+}
+
+// EmitStorageFind pushes an iterator over the entries of the running
+// contract whose keys start with prefix.
+func (sb *ScriptBuilder) EmitStorageFind(prefix []byte) error {
+	sb.EmitPushBytes(prefix)
+	if err := sb.EmitSysCall("Neo.Storage.GetContext"); err != nil {
+		return err
+	}
+	return sb.EmitSysCall("Neo.Storage.Find")
+}
+
+func (sb *ScriptBuilder) EmitGetHeight() error {
+	return sb.EmitSysCall("Neo.Blockchain.GetHeight")
+}
+
+// EmitGetBalance pushes the balance of assetId held by account.
+func (sb *ScriptBuilder) EmitGetBalance(account UInt160, assetId UInt256) error {
+	sb.EmitPushBytes(assetId.Bytes())
+	sb.EmitPushBytes(account.Bytes())
+	if err := sb.EmitSysCall("Neo.Blockchain.GetAccount"); err != nil {
+		return err
+	}
+	return sb.EmitSysCall("Neo.Account.GetBalance")
+}
@@ -0,0 +1,173 @@
+package Neo
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/neo-thinsdk-go/utils"
+)
+
+// Payment sends Value of AssetId to Address.
+type Payment struct {
+	Address string
+	AssetId UInt256
+	Value   Fixed8
+}
+
+// SignedTransaction is a transaction ready to relay.
+type SignedTransaction struct {
+	Txid string
+	Raw  string
+	Tx   *Transaction
+}
+
+func signTransaction(tx *Transaction, privKey *ecdsa.PrivateKey) (SignedTransaction, error) {
+	if err := tx.Sign(privKey); err != nil {
+		return SignedTransaction{}, err
+	}
+	rawData, err := tx.GetRawData()
+	if err != nil {
+		return SignedTransaction{}, err
+	}
+	txid, err := tx.GetTxid()
+	if err != nil {
+		return SignedTransaction{}, err
+	}
+	return SignedTransaction{Txid: txid, Raw: utils.ToHexString(rawData), Tx: tx}, nil
+}
+
+// removeSpent drops the utxos spent by tx from pool.
+func removeSpent(pool map[UInt256][]Utxo, tx *Transaction) {
+	spent := make(map[TransactionInput]bool, len(tx.inputs))
+	for _, input := range tx.inputs {
+		spent[input] = true
+	}
+	for assetId, utxos := range pool {
+		var left []Utxo
+		for _, utxo := range utxos {
+			if !spent[NewTransactionInput(utxo.Hash, utxo.N)] {
+				left = append(left, utxo)
+			}
+		}
+		pool[assetId] = left
+	}
+}
+
+// BatchPayout pays many recipients from the single signature account of
+// PrivateKey, packing as many payments per ContractTransaction as
+// MaxTransactionSize allows. Change goes back to the paying account.
+type BatchPayout struct {
+	PrivateKey *ecdsa.PrivateKey
+	// Utxos holds the spendable outputs of the account per asset.
+	Utxos map[UInt256][]Utxo
+	// Selection picks the inputs; nil means LargestFirst without limits.
+	Selection *CoinSelection
+	// FeePolicy, when set, adds the network fee it asks for in GAS.
+	FeePolicy *FeePolicy
+	// MaxTransactionSize defaults to 102400 bytes.
+	MaxTransactionSize int
+}
+
+// Pay builds and signs the transactions for payments, in order. Utxos
+// spent by one transaction are not offered to the next. The length of
+// each batch is found with a binary search, so a batch of n payments
+// takes about 2 log n builds. When a payment cannot be paid, the batches
+// before it are signed and returned along with the error.
+func (bp *BatchPayout) Pay(payments []Payment) ([]SignedTransaction, error) {
+	maxSize := bp.MaxTransactionSize
+	if maxSize <= 0 {
+		maxSize = 102400
+	}
+	pool := make(map[UInt256][]Utxo, len(bp.Utxos))
+	for assetId, utxos := range bp.Utxos {
+		pool[assetId] = append([]Utxo(nil), utxos...)
+	}
+
+	var signed []SignedTransaction
+	for start := 0; start < len(payments); {
+		remaining := payments[start:]
+		// fit builds the first n remaining payments and reports whether
+		// they make one transaction. The search only tries n above the
+		// last fit and below the last failure, so tx and failErr always
+		// belong to the boundary.
+		var tx *Transaction
+		var failErr error
+		failSize := 0
+		fit := func(n int) bool {
+			next, size, err := bp.build(pool, remaining[:n])
+			if err == nil && size <= maxSize {
+				tx = next
+				return true
+			}
+			failErr, failSize = err, size
+			return false
+		}
+		// double the batch until it breaks, then search the boundary
+		fits, fails := 0, len(remaining)+1
+		for n := 1; ; n *= 2 {
+			if n > len(remaining) {
+				n = len(remaining)
+			}
+			if !fit(n) {
+				fails = n
+				break
+			}
+			if fits = n; n == len(remaining) {
+				break
+			}
+		}
+		for fails-fits > 1 {
+			if mid := (fits + fails) / 2; fit(mid) {
+				fits = mid
+			} else {
+				fails = mid
+			}
+		}
+		if fits == 0 {
+			if failErr == nil {
+				failErr = fmt.Errorf("payment alone makes a transaction of %d bytes", failSize)
+			}
+			return signed, fmt.Errorf("payment %d: %w", start, failErr)
+		}
+		result, err := signTransaction(tx, bp.PrivateKey)
+		if err != nil {
+			return signed, err
+		}
+		signed = append(signed, result)
+		removeSpent(pool, tx)
+		// only the size and input limits close a batch, other errors
+		// would fail the next batch as well
+		if failErr != nil && !errors.Is(failErr, ErrTooManyInputs) {
+			return signed, fmt.Errorf("payment %d: %w", start+fits, failErr)
+		}
+		start += fits
+	}
+	return signed, nil
+}
+
+// build returns the unsigned transaction paying payments and its size
+// once signed.
+func (bp *BatchPayout) build(pool map[UInt256][]Utxo, payments []Payment) (*Transaction, int, error) {
+	selection := bp.Selection
+	if selection == nil {
+		selection = &CoinSelection{}
+	}
+	from := getAddressFromPublicKey(&bp.PrivateKey.PublicKey)
+	tb := NewTransactionBuilder(ContractTransaction)
+	for _, payment := range payments {
+		tb.AddOutput(payment.AssetId, payment.Value, payment.Address)
+	}
+	tx, err := tb.SelectInputs(selection, pool).
+		SetChangeAddress(from).
+		AutoNetworkFee(bp.FeePolicy, SingleSigWitnessSize()).
+		Build()
+	if err != nil {
+		return nil, 0, err
+	}
+	size, err := tx.EstimateSize(SingleSigWitnessSize())
+	if err != nil {
+		return nil, 0, err
+	}
+	return tx, size, nil
+}
@@ -0,0 +1,126 @@
+package Neo
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+)
+
+func testPayout(t *testing.T, maxSize int) *BatchPayout {
+	t.Helper()
+	key := &ecdsa.PrivateKey{}
+	if err := FromWIF(key, keyVectors[0].wif); err != nil {
+		t.Fatal(err)
+	}
+	// change is not spent again, so give every batch its own utxos
+	gas := coins(10, 10, 10, 10, 10, 10, 10, 10, 10, 10)
+	neo := coins(5, 5, 5, 5, 5, 5, 5, 5, 5, 5)
+	for i := range neo {
+		neo[i].Hash[2] = 1
+	}
+	return &BatchPayout{
+		PrivateKey:         key,
+		Utxos:              map[UInt256][]Utxo{GasAssetHash: gas, NeoAssetHash: neo},
+		FeePolicy:          DefaultFeePolicy(),
+		MaxTransactionSize: maxSize,
+	}
+}
+
+func testPayments(n int) []Payment {
+	payments := make([]Payment, n)
+	for i := range payments {
+		payments[i] = Payment{Address: keyVectors[1].address, AssetId: GasAssetHash, Value: Fixed8{value: int64(i+1) * D / 100}}
+		if i%5 == 4 {
+			payments[i].AssetId = NeoAssetHash
+			payments[i].Value = Fixed8{value: D}
+		}
+	}
+	return payments
+}
+
+func TestBatchPayoutSplitsPayments(t *testing.T) {
+	const maxSize = 1500
+	bp := testPayout(t, maxSize)
+	payments := testPayments(100)
+	signed, err := bp.Pay(payments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signed) < 3 {
+		t.Fatalf("%d transactions, the test needs more", len(signed))
+	}
+
+	payee, _ := UInt160FromAddress(keyVectors[1].address)
+	payer, _ := UInt160FromAddress(keyVectors[0].address)
+	refs := References{}
+	for assetId, utxos := range bp.Utxos {
+		for _, utxo := range utxos {
+			refs[NewTransactionInput(utxo.Hash, utxo.N)] = NewTransactionOutput(assetId, utxo.Value, payer)
+		}
+	}
+	var paid []Payment
+	withFee := 0
+	spent := make(map[TransactionInput]bool)
+	for i, result := range signed {
+		tx := result.Tx
+		size, err := tx.Size()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size > maxSize {
+			t.Errorf("transaction %d: %d bytes", i, size)
+		}
+		fee, _ := bp.FeePolicy.MinimumFee(size)
+		if tx.networkFee != fee {
+			t.Errorf("transaction %d of %d bytes: network fee %s, want %s", i, size, tx.networkFee, fee)
+		}
+		if fee.Sign() > 0 {
+			withFee++
+		}
+		if results, err := tx.VerifyWitnesses(refs); err != nil {
+			t.Fatal(err)
+		} else if len(results) != 1 || !results[0].Ok() {
+			t.Errorf("transaction %d: witnesses %v", i, results)
+		}
+		for _, input := range tx.inputs {
+			if spent[input] {
+				t.Errorf("transaction %d: input %s:%d spent twice", i, input.hash, input.index)
+			}
+			spent[input] = true
+		}
+		for _, output := range tx.outputs {
+			if output.scriptHash == payee {
+				paid = append(paid, Payment{Address: keyVectors[1].address, AssetId: output.assetId, Value: output.value})
+			}
+		}
+	}
+	if withFee == 0 {
+		t.Error("no transaction paid a network fee, the test needs bigger batches")
+	}
+	if len(paid) != len(payments) {
+		t.Fatalf("%d payments made, want %d", len(paid), len(payments))
+	}
+	for i := range payments {
+		if paid[i] != payments[i] {
+			t.Errorf("payment %d: %+v, want %+v", i, paid[i], payments[i])
+		}
+	}
+}
+
+func TestBatchPayoutSignsBatchesBeforeError(t *testing.T) {
+	bp := testPayout(t, 1500)
+	payments := testPayments(60)
+	payments[50].Address = "AXaXZjZGA3qhQRTCsyG5uFKr9HeShgVhTG"
+	signed, err := bp.Pay(payments)
+	if !errors.Is(err, ErrBadAddress) {
+		t.Fatalf("got %v, want ErrBadAddress", err)
+	}
+	outputs := 0
+	for _, result := range signed {
+		outputs += len(result.Tx.outputs)
+	}
+	// every payment before the bad one is paid, plus change outputs
+	if outputs < 50 {
+		t.Errorf("%d outputs signed before payment 50", outputs)
+	}
+}
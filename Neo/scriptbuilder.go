@@ -2,7 +2,11 @@ package Neo
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
 	"math/big"
+	"sort"
 	"github.com/neo-thinsdk-go/OpCode"
 	"github.com/neo-thinsdk-go/utils"
 	"github.com/neo-thinsdk-go/simplejson"
@@ -10,11 +14,36 @@ import (
 )
 
 type ScriptBuilder struct {
-	buf bytes.Buffer
+	buf    bytes.Buffer
+	labels map[string]int
+	jumps  []labelJump
+
+	validateSysCalls bool
+}
+
+// labelJump is a jump emitted before its label offset was known.
+type labelJump struct {
+	position int
+	label    string
 }
 
-func (sb *ScriptBuilder) toBytes() []byte {
-	return sb.buf.Bytes()
+// ToArray returns the script, with the offsets of jumps to labels filled
+// in. It fails when a label was never marked or is out of reach.
+func (sb *ScriptBuilder) ToArray() ([]byte, error) {
+	script := append([]byte(nil), sb.buf.Bytes()...)
+	for _, jump := range sb.jumps {
+		target, ok := sb.labels[jump.label]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is never marked", ErrBadLabel, jump.label)
+		}
+		offset := target - jump.position
+		if offset < math.MinInt16 || offset > math.MaxInt16 {
+			return nil, fmt.Errorf("%w: %q is %d bytes away", ErrBadLabel, jump.label, offset)
+		}
+		script[jump.position+1] = byte(offset)
+		script[jump.position+2] = byte(offset >> 8)
+	}
+	return script, nil
 }
 
 func (sb *ScriptBuilder) Emit(opcode byte, arg []byte)   {
@@ -24,47 +53,73 @@ func (sb *ScriptBuilder) Emit(opcode byte, arg []byte)   {
 	}
 }
 
-func (sb *ScriptBuilder) EmitAppCall(scriptHash []byte, useTailCall bool)  {
-	if len(scriptHash) != 20 {
-		panic("runtime error: script hash length error")
-	}
-
+func (sb *ScriptBuilder) EmitAppCall(scriptHash UInt160, useTailCall bool) {
 	opcode := OpCode.TAILCALL
 	if !useTailCall {
 		opcode = OpCode.APPCALL
 	}
-	sb.Emit(opcode, scriptHash)
+	sb.Emit(opcode, scriptHash[:])
 }
 
-func (sb *ScriptBuilder) EmitJump(opcode byte, offset int16) {
-	if opcode != OpCode.JMP && opcode != OpCode.JMPIF && opcode != OpCode.JMPIFNOT && opcode != OpCode.CALL {
-		panic("runtime error: opcode error")
+// EmitJump emits a JMP, JMPIF, JMPIFNOT or CALL by offset from the
+// position of the opcode.
+func (sb *ScriptBuilder) EmitJump(opcode byte, offset int16) error {
+	if !isJump(opcode) {
+		return fmt.Errorf("%w: 0x%02x is not a jump", ErrBadOpCode, opcode)
 	}
 	var buf bytes.Buffer
 	utils.WriteUint16(&buf, uint16(offset))
 	sb.Emit(opcode, buf.Bytes())
+	return nil
 }
 
-func (sb *ScriptBuilder) EmitPushNumber(number big.Int)  {
-	var minusOne = big.NewInt(-1)
-	if number.Cmp(minusOne) == 0 {
-		sb.Emit(OpCode.PUSHM1, []byte{})
-		return
+// EmitJumpTo emits a JMP, JMPIF, JMPIFNOT or CALL to label, which may be
+// marked before or after it. The offset is filled in by ToArray.
+func (sb *ScriptBuilder) EmitJumpTo(opcode byte, label string) error {
+	if !isJump(opcode) {
+		return fmt.Errorf("%w: 0x%02x is not a jump", ErrBadOpCode, opcode)
+	}
+	sb.jumps = append(sb.jumps, labelJump{position: sb.buf.Len(), label: label})
+	sb.Emit(opcode, []byte{0, 0})
+	return nil
+}
+
+// MarkLabel names the position of the next opcode.
+func (sb *ScriptBuilder) MarkLabel(label string) error {
+	if _, ok := sb.labels[label]; ok {
+		return fmt.Errorf("%w: %q is marked twice", ErrBadLabel, label)
 	}
-	var zero = big.NewInt(0)
-	if number.Cmp(zero) == 0 {
-		sb.Emit(OpCode.PUSH0, []byte{})
-		return
+	if sb.labels == nil {
+		sb.labels = make(map[string]int)
 	}
+	sb.labels[label] = sb.buf.Len()
+	return nil
+}
+
+func isJump(opcode byte) bool {
+	return opcode == OpCode.JMP || opcode == OpCode.JMPIF || opcode == OpCode.JMPIFNOT || opcode == OpCode.CALL
+}
 
-	var sixteen = big.NewInt(16)
-	if number.Cmp(zero) == 1 && number.Cmp(sixteen) == -1 {
-		opcode := OpCode.PUSH1 - 1 + (uint8)(number.Uint64())
-		sb.Emit(opcode, []byte{})
-		return
+// EmitPushNumber pushes number with PUSHM1, PUSH0 to PUSH16 when it is in
+// their range, else as bytes in the encoding of IntegerToBytes.
+func (sb *ScriptBuilder) EmitPushNumber(number big.Int)  {
+	if number.IsInt64() {
+		value := number.Int64()
+		if value == -1 {
+			sb.Emit(OpCode.PUSHM1, []byte{})
+			return
+		}
+		if value == 0 {
+			sb.Emit(OpCode.PUSH0, []byte{})
+			return
+		}
+		if value >= 1 && value <= 16 {
+			sb.Emit(OpCode.PUSH1 - 1 + byte(value), []byte{})
+			return
+		}
 	}
 
-	sb.EmitPushBytes(number.Bytes())
+	sb.EmitPushBytes(IntegerToBytes(&number))
 }
 
 func (sb *ScriptBuilder) EmitPushBool(b bool)  {
@@ -99,18 +154,22 @@ func (sb *ScriptBuilder) EmitPushString(data string)  {
 	sb.EmitPushBytes([]byte(data))
 }
 
-func (sb *ScriptBuilder) EmitSysCall(api string)  {
+func (sb *ScriptBuilder) EmitSysCall(api string) error {
 	hexdata := []byte(api)
 	length := len(hexdata)
 	if length <= 0 || length > 252 {
-		panic("runtime error: api length error")
+		return fmt.Errorf("%w: length %d", ErrBadSysCall, length)
+	}
+	if _, ok := interopServices[api]; sb.validateSysCalls && !ok {
+		return fmt.Errorf("%w: unknown interop service %q", ErrBadSysCall, api)
 	}
 
 	var buf bytes.Buffer
 	buf.WriteByte(uint8(length))
 	buf.Write(hexdata)
 	sb.Emit(OpCode.SYSCALL, buf.Bytes())
- }
+	return nil
+}
 
 func getParamBytes(buf * bytes.Buffer, str string) bool {
 	bytes := []byte(str)
@@ -143,16 +202,18 @@ func getParamBytes(buf * bytes.Buffer, str string) bool {
 		buf.Write(pubHash)
 	} else if strings.Index(str, "(integer)") == 0 {
 		strData := utils.Substr(str, 9, length - 9)
-		value := &big.Int{}
-		value, _ = value.SetString(strData, 10)
-		data := value.Bytes()
-		buf.Write(data)
+		value, ok := new(big.Int).SetString(strData, 10)
+		if !ok {
+			return false
+		}
+		buf.Write(IntegerToBytes(value))
 	} else if strings.Index(str, "(int)") == 0 {
 		strData := utils.Substr(str, 5, length - 5)
-		value := &big.Int{}
-		value, _ = value.SetString(strData, 10)
-		data := value.Bytes()
-		buf.Write(data)
+		value, ok := new(big.Int).SetString(strData, 10)
+		if !ok {
+			return false
+		}
+		buf.Write(IntegerToBytes(value))
 
 	} else if strings.Index(str, "(hexinteger)") == 0 {
 		strData := utils.Substr(str, 12, length - 12)
@@ -203,8 +264,19 @@ func getParamBytes(buf * bytes.Buffer, str string) bool {
 	return true
 }
 
-func (sb *ScriptBuilder) pushParam(param interface{}) {
+// Struct is a list of parameters pushed as a NeoVM struct instead of an
+// array.
+type Struct []interface{}
+
+// pushParam pushes a parameter decoded from JSON or built in Go. Lists are
+// pushed as their items in reverse order, their count and PACK, followed
+// by NEWSTRUCT for a Struct. A map is read as a ContractParameter, or as a
+// struct when its type is "Struct"; a map without a type is the list of
+// its values ordered by key. Strings must carry a "(type)" prefix.
+func (sb *ScriptBuilder) pushParam(param interface{}) error {
 	switch v := param.(type) {
+	case ContractParameter:
+		return sb.EmitParameter(v)
 	case bool:
 		sb.EmitPushBool(v)
 	case int:
@@ -213,24 +285,89 @@ func (sb *ScriptBuilder) pushParam(param interface{}) {
 	case int64:
 		var value = big.NewInt(v)
 		sb.EmitPushNumber(*value)
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return fmt.Errorf("%w: %v is not an integer", ErrBadParam, v)
+		}
+		var value = big.NewInt(int64(v))
+		sb.EmitPushNumber(*value)
+	case *big.Int:
+		sb.EmitPushNumber(*v)
 	case []interface{}:
-		length := len(v)
-		for i := length - 1; i >= 0; i-- {
-			sb.pushParam(v[i])
+		return sb.pushList(v)
+	case Struct:
+		if err := sb.pushList(v); err != nil {
+			return err
 		}
+		sb.Emit(OpCode.NEWSTRUCT, []byte{})
 	case map[string]interface{}:
-		for _, value := range v {
-			sb.pushParam(value)
+		if v["type"] == "Struct" {
+			items, ok := v["value"].([]interface{})
+			if !ok {
+				return fmt.Errorf("%w: struct without a value list", ErrBadParam)
+			}
+			return sb.pushParam(Struct(items))
+		}
+		if _, ok := v["type"]; !ok {
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			items := make([]interface{}, len(keys))
+			for i, key := range keys {
+				items[i] = v[key]
+			}
+			return sb.pushList(items)
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
 		}
+		var cp ContractParameter
+		if err := json.Unmarshal(data, &cp); err != nil {
+			return err
+		}
+		return sb.EmitParameter(cp)
 	case string:
 		var buf bytes.Buffer
-		getParamBytes(&buf, v)
+		if v == "" || !getParamBytes(&buf, v) {
+			return fmt.Errorf("%w: %q has no known (type) prefix", ErrBadParam, v)
+		}
 		sb.EmitPushBytes(buf.Bytes())
 	default:
-		panic("runtime error: data type error")
+		return fmt.Errorf("%w: unsupported type %T", ErrBadParam, param)
+	}
+	return nil
+}
+
+func (sb *ScriptBuilder) pushList(items []interface{}) error {
+	for i := len(items) - 1; i >= 0; i-- {
+		if err := sb.pushParam(items[i]); err != nil {
+			return err
+		}
 	}
+	sb.EmitPushNumber(*big.NewInt(int64(len(items))))
+	sb.Emit(OpCode.PACK, []byte{})
+	return nil
+}
+
+// EmitParamJson pushes a parameter decoded from JSON. Arrays are packed,
+// so contracts receive them as arrays, and objects without a "type" are
+// pushed as the array of their values in key order; scripts built before
+// arrays were packed differ from the ones built now.
+func (sb *ScriptBuilder) EmitParamJson(param *simplejson.Json) error {
+	return sb.pushParam(param.Data)
 }
 
-func (sb *ScriptBuilder) EmitParamJson(param *simplejson.Json)  {
-	sb.pushParam(param.Data)
-}
\ No newline at end of file
+// EmitContractCall calls operation of the contract at scriptHash with args
+// packed into an array, as neo-gui does. args take the same values as a
+// list given to EmitParamJson.
+func (sb *ScriptBuilder) EmitContractCall(scriptHash UInt160, operation string, args ...interface{}) error {
+	if err := sb.pushList(args); err != nil {
+		return err
+	}
+	sb.EmitPushString(operation)
+	sb.EmitAppCall(scriptHash, false)
+	return nil
+}
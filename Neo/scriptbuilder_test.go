@@ -0,0 +1,159 @@
+package Neo
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/neo-thinsdk-go/OpCode"
+	"github.com/neo-thinsdk-go/simplejson"
+	"github.com/neo-thinsdk-go/utils"
+)
+
+// The script neo-gui builds to transfer 1.00000000 RPX from the first key
+// of keyVectors to the second: amount, to, from, PUSH3 PACK, "transfer"
+// and APPCALL of the contract hash in little-endian order.
+const (
+	rpxScriptHash = "0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9"
+	nep5Transfer  = "0400e1f505" + "1423ba2703c53263e8d6e522dc32203339dcd8eee9" + "14ad5cac596a1ef6c18ac1746dfd304f93964354b5" + "53c1" +
+		"087472616e73666572" + "67f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec"
+)
+
+func TestNep5TransferScript(t *testing.T) {
+	script, ok := GetNep5Transfer(rpxScriptHash, keyVectors[0].address, keyVectors[1].address, *big.NewInt(100000000))
+	if !ok {
+		t.Fatal("GetNep5Transfer failed")
+	}
+	if got := utils.ToHexString(script); got != nep5Transfer {
+		t.Errorf("GetNep5Transfer = %s, want %s", got, nep5Transfer)
+	}
+
+	args, err := simplejson.Loads(`["(address)` + keyVectors[0].address + `", "(address)` + keyVectors[1].address + `", "(integer)100000000"]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scriptHash, _ := ParseUInt160(rpxScriptHash)
+	sb := &ScriptBuilder{}
+	if err := sb.EmitParamJson(args); err != nil {
+		t.Fatal(err)
+	}
+	sb.EmitPushString("transfer")
+	sb.EmitAppCall(scriptHash, false)
+	script, err = sb.ToArray()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := utils.ToHexString(script); got != nep5Transfer {
+		t.Errorf("EmitParamJson = %s, want %s", got, nep5Transfer)
+	}
+}
+
+func TestEmitParamJson(t *testing.T) {
+	tests := []struct {
+		json   string
+		script string
+	}{
+		{`["(int)1", ["(int)2", "(int)3"]]`, "0103" + "0102" + "52c1" + "0101" + "52c1"},
+		{`{"b": "(int)2", "a": "(int)1"}`, "0102" + "0101" + "52c1"},
+		{`{"type": "Struct", "value": [true, 5]}`, "55" + "51" + "52c1" + "c6"},
+		{`{"type": "Hash160", "value": "` + rpxScriptHash + `"}`, "14f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec"},
+		{`[]`, "00c1"},
+	}
+	for _, test := range tests {
+		param, err := simplejson.Loads(test.json)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sb := &ScriptBuilder{}
+		if err := sb.EmitParamJson(param); err != nil {
+			t.Errorf("%s: %v", test.json, err)
+			continue
+		}
+		script, _ := sb.ToArray()
+		if got := utils.ToHexString(script); got != test.script {
+			t.Errorf("%s: script %s, want %s", test.json, got, test.script)
+		}
+	}
+
+	for _, bad := range []string{`"no prefix"`, `1.5`, `{"type": "Struct"}`, `{"type": "Nope", "value": 1}`} {
+		param, err := simplejson.Loads(bad)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := (&ScriptBuilder{}).EmitParamJson(param); err == nil {
+			t.Errorf("%s: no error", bad)
+		}
+	}
+}
+
+func TestLabels(t *testing.T) {
+	sb := &ScriptBuilder{}
+	sb.MarkLabel("top")
+	sb.EmitNop()
+	sb.EmitJumpTo(OpCode.JMPIFNOT, "end")
+	sb.EmitJumpTo(OpCode.CALL, "here")
+	sb.MarkLabel("here")
+	sb.EmitJumpTo(OpCode.JMP, "top")
+	sb.MarkLabel("end")
+	sb.EmitRet()
+	script, err := sb.ToArray()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// JMPIFNOT at 1 to 10, CALL at 4 to 7, JMP at 7 back to 0
+	want := "61" + "640900" + "650300" + "62f9ff" + "66"
+	if got := utils.ToHexString(script); got != want {
+		t.Errorf("script %s, want %s", got, want)
+	}
+
+	// the same offsets given by hand
+	sb = &ScriptBuilder{}
+	sb.EmitNop()
+	sb.EmitJump(OpCode.JMPIFNOT, 9)
+	sb.EmitJump(OpCode.CALL, 3)
+	sb.EmitJump(OpCode.JMP, -7)
+	sb.EmitRet()
+	script, _ = sb.ToArray()
+	if got := utils.ToHexString(script); got != want {
+		t.Errorf("EmitJump script %s, want %s", got, want)
+	}
+}
+
+func TestLabelErrors(t *testing.T) {
+	sb := &ScriptBuilder{}
+	sb.EmitJumpTo(OpCode.JMP, "nowhere")
+	if _, err := sb.ToArray(); !errors.Is(err, ErrBadLabel) {
+		t.Errorf("unresolved label: got %v, want ErrBadLabel", err)
+	}
+
+	sb = &ScriptBuilder{}
+	sb.MarkLabel("twice")
+	if err := sb.MarkLabel("twice"); !errors.Is(err, ErrBadLabel) {
+		t.Errorf("label marked twice: got %v, want ErrBadLabel", err)
+	}
+
+	sb = &ScriptBuilder{}
+	if err := sb.EmitJumpTo(OpCode.RET, "top"); !errors.Is(err, ErrBadOpCode) {
+		t.Errorf("RET as a jump: got %v, want ErrBadOpCode", err)
+	}
+	if err := sb.EmitJump(OpCode.NOP, 0); !errors.Is(err, ErrBadOpCode) {
+		t.Errorf("NOP as a jump: got %v, want ErrBadOpCode", err)
+	}
+
+	// a label beyond the reach of a 16 bit offset
+	sb = &ScriptBuilder{}
+	sb.EmitJumpTo(OpCode.JMP, "far")
+	sb.EmitPushBytes(make([]byte, math.MaxInt16))
+	sb.MarkLabel("far")
+	if _, err := sb.ToArray(); !errors.Is(err, ErrBadLabel) {
+		t.Errorf("label out of reach: got %v, want ErrBadLabel", err)
+	}
+	sb = &ScriptBuilder{}
+	sb.MarkLabel("near")
+	sb.EmitPushBytes(make([]byte, math.MaxInt16-10))
+	sb.EmitJumpTo(OpCode.JMP, "near")
+	if _, err := sb.ToArray(); err != nil {
+		t.Errorf("label within reach: %v", err)
+	}
+}
@@ -0,0 +1,313 @@
+package Neo
+
+import (
+	"github.com/neo-thinsdk-go/OpCode"
+)
+
+// Helpers for the opcodes that take no operand. Numbers and data are
+// pushed with EmitPushNumber, EmitPushBool and EmitPushBytes, jumps with
+// EmitJump and EmitJumpTo, and calls with EmitAppCall and EmitSysCall.
+
+// Flow control
+
+func (sb *ScriptBuilder) EmitNop() {
+	sb.Emit(OpCode.NOP, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitRet() {
+	sb.Emit(OpCode.RET, []byte{})
+}
+
+// Stack
+
+func (sb *ScriptBuilder) EmitDupFromAltStack() {
+	sb.Emit(OpCode.DUPFROMALTSTACK, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitToAltStack() {
+	sb.Emit(OpCode.TOALTSTACK, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitFromAltStack() {
+	sb.Emit(OpCode.FROMALTSTACK, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitXDrop() {
+	sb.Emit(OpCode.XDROP, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitXSwap() {
+	sb.Emit(OpCode.XSWAP, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitXTuck() {
+	sb.Emit(OpCode.XTUCK, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitDepth() {
+	sb.Emit(OpCode.DEPTH, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitDrop() {
+	sb.Emit(OpCode.DROP, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitDup() {
+	sb.Emit(OpCode.DUP, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitNip() {
+	sb.Emit(OpCode.NIP, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitOver() {
+	sb.Emit(OpCode.OVER, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitPick() {
+	sb.Emit(OpCode.PICK, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitRoll() {
+	sb.Emit(OpCode.ROLL, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitRot() {
+	sb.Emit(OpCode.ROT, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitSwap() {
+	sb.Emit(OpCode.SWAP, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitTuck() {
+	sb.Emit(OpCode.TUCK, []byte{})
+}
+
+// Splice
+
+func (sb *ScriptBuilder) EmitCat() {
+	sb.Emit(OpCode.CAT, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitSubStr() {
+	sb.Emit(OpCode.SUBSTR, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitLeft() {
+	sb.Emit(OpCode.LEFT, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitRight() {
+	sb.Emit(OpCode.RIGHT, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitSize() {
+	sb.Emit(OpCode.SIZE, []byte{})
+}
+
+// Bitwise logic
+
+func (sb *ScriptBuilder) EmitInvert() {
+	sb.Emit(OpCode.INVERT, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitAnd() {
+	sb.Emit(OpCode.AND, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitOr() {
+	sb.Emit(OpCode.OR, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitXor() {
+	sb.Emit(OpCode.XOR, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitEqual() {
+	sb.Emit(OpCode.EQUAL, []byte{})
+}
+
+// Arithmetic
+
+func (sb *ScriptBuilder) EmitInc() {
+	sb.Emit(OpCode.INC, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitDec() {
+	sb.Emit(OpCode.DEC, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitSign() {
+	sb.Emit(OpCode.SIGN, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitNegate() {
+	sb.Emit(OpCode.NEGATE, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitAbs() {
+	sb.Emit(OpCode.ABS, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitNot() {
+	sb.Emit(OpCode.NOT, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitNz() {
+	sb.Emit(OpCode.NZ, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitAdd() {
+	sb.Emit(OpCode.ADD, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitSub() {
+	sb.Emit(OpCode.SUB, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitMul() {
+	sb.Emit(OpCode.MUL, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitDiv() {
+	sb.Emit(OpCode.DIV, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitMod() {
+	sb.Emit(OpCode.MOD, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitShl() {
+	sb.Emit(OpCode.SHL, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitShr() {
+	sb.Emit(OpCode.SHR, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitBoolAnd() {
+	sb.Emit(OpCode.BOOLAND, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitBoolOr() {
+	sb.Emit(OpCode.BOOLOR, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitNumEqual() {
+	sb.Emit(OpCode.NUMEQUAL, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitNumNotEqual() {
+	sb.Emit(OpCode.NUMNOTEQUAL, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitLt() {
+	sb.Emit(OpCode.LT, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitGt() {
+	sb.Emit(OpCode.GT, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitLte() {
+	sb.Emit(OpCode.LTE, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitGte() {
+	sb.Emit(OpCode.GTE, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitMin() {
+	sb.Emit(OpCode.MIN, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitMax() {
+	sb.Emit(OpCode.MAX, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitWithin() {
+	sb.Emit(OpCode.WITHIN, []byte{})
+}
+
+// Crypto
+
+func (sb *ScriptBuilder) EmitSha1() {
+	sb.Emit(OpCode.SHA1, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitSha256() {
+	sb.Emit(OpCode.SHA256, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitHash160() {
+	sb.Emit(OpCode.HASH160, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitHash256() {
+	sb.Emit(OpCode.HASH256, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitCSharpStrHash32() {
+	sb.Emit(OpCode.CSHARPSTRHASH32, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitJavaHash32() {
+	sb.Emit(OpCode.JAVAHASH32, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitCheckSig() {
+	sb.Emit(OpCode.CHECKSIG, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitCheckMultiSig() {
+	sb.Emit(OpCode.CHECKMULTISIG, []byte{})
+}
+
+// Array
+
+func (sb *ScriptBuilder) EmitArraySize() {
+	sb.Emit(OpCode.ARRAYSIZE, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitPack() {
+	sb.Emit(OpCode.PACK, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitUnpack() {
+	sb.Emit(OpCode.UNPACK, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitPickItem() {
+	sb.Emit(OpCode.PICKITEM, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitSetItem() {
+	sb.Emit(OpCode.SETITEM, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitNewArray() {
+	sb.Emit(OpCode.NEWARRAY, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitNewStruct() {
+	sb.Emit(OpCode.NEWSTRUCT, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitNewMap() {
+	sb.Emit(OpCode.NEWMAP, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitSwitch() {
+	sb.Emit(OpCode.SWITCH, []byte{})
+}
+
+// Exceptions
+
+func (sb *ScriptBuilder) EmitThrow() {
+	sb.Emit(OpCode.THROW, []byte{})
+}
+
+func (sb *ScriptBuilder) EmitThrowIfNot() {
+	sb.Emit(OpCode.THROWIFNOT, []byte{})
+}
@@ -0,0 +1,131 @@
+package Neo
+
+import (
+	"github.com/neo-thinsdk-go/utils"
+)
+
+// byteCounter is an io.Writer that only counts what is written to it.
+type byteCounter struct {
+	n int
+}
+
+func (c *byteCounter) Write(data []byte) (int, error) {
+	c.n += len(data)
+	return len(data), nil
+}
+
+// Size returns the serialized size of the transaction with the witnesses
+// it carries now.
+func (self *Transaction) Size() (int, error) {
+	counter := &byteCounter{}
+	if err := self.Serialize(counter); err != nil {
+		return 0, err
+	}
+	return counter.n, nil
+}
+
+// EstimateSize returns the size the transaction will have once witnesses
+// of the given sizes are added to the ones it already has. Use
+// SingleSigWitnessSize and MultiSigWitnessSize for standard signers.
+func (self *Transaction) EstimateSize(witnessSizes ...int) (int, error) {
+	counter := &byteCounter{}
+	if err := self.SerializeUnsigned(counter); err != nil {
+		return 0, err
+	}
+	size := counter.n + utils.GetVarSize(uint64(len(self.witnesses)+len(witnessSizes)))
+	for _, witness := range self.witnesses {
+		size += varBytesSize(len(witness.InvocationScript)) + varBytesSize(len(witness.VerificationScript))
+	}
+	for _, witnessSize := range witnessSizes {
+		size += witnessSize
+	}
+	return size, nil
+}
+
+func varBytesSize(length int) int {
+	return utils.GetVarSize(uint64(length)) + length
+}
+
+// inputLimit returns how many inputs, or claims, fit in a transaction of
+// at most maxSize bytes that is size bytes with one of them. Each one
+// adds 34 bytes, and the count grows from one to three bytes at 253.
+func inputLimit(size int, maxSize int) int {
+	limit := 1
+	for limit < 65535 {
+		next := limit + 1
+		if size+34*(next-1)+utils.GetVarSize(uint64(next))-1 > maxSize {
+			break
+		}
+		limit = next
+	}
+	return limit
+}
+
+// pushIntSize is the size of pushing a signer count in a multi signature
+// script: PUSH1-PUSH16, else the signed little-endian bytes of n.
+func pushIntSize(n int) int {
+	if n >= 1 && n <= 16 {
+		return 1
+	}
+	if n <= 0x7f {
+		return 2
+	}
+	return 3
+}
+
+// SingleSigWitnessSize is the size of the witness of a standard single
+// signature account.
+func SingleSigWitnessSize() int {
+	return varBytesSize(65) + varBytesSize(35)
+}
+
+// MultiSigWitnessSize is the size of the witness of an m-of-n multi
+// signature account.
+func MultiSigWitnessSize(m, n int) int {
+	verification := pushIntSize(m) + n*34 + pushIntSize(n) + 1
+	return varBytesSize(m*65) + varBytesSize(verification)
+}
+
+// FeePolicy decides the minimum network fee of a transaction from its
+// size. Transactions up to FreeSize bytes need no fee; larger ones pay
+// FeePerByte for every byte above FreeSize, but at least BaseFee.
+type FeePolicy struct {
+	FreeSize   int
+	FeePerByte Fixed8
+	BaseFee    Fixed8
+}
+
+// DefaultFeePolicy returns the policy of Neo 2 nodes: free up to 1024
+// bytes, then 0.00001 GAS per extra byte with 0.001 GAS as the floor of
+// the low priority pool.
+func DefaultFeePolicy() *FeePolicy {
+	return &FeePolicy{
+		FreeSize:   1024,
+		FeePerByte: Fixed8{value: 1000},
+		BaseFee:    Fixed8{value: 100000},
+	}
+}
+
+func (policy *FeePolicy) MinimumFee(size int) (Fixed8, error) {
+	if size <= policy.FreeSize {
+		return Fixed8{}, nil
+	}
+	fee, err := policy.FeePerByte.MulInt(int64(size - policy.FreeSize))
+	if err != nil {
+		return Fixed8{}, err
+	}
+	if fee.LessThan(policy.BaseFee) {
+		return policy.BaseFee, nil
+	}
+	return fee, nil
+}
+
+// MinimumNetworkFee returns the fee the transaction needs under policy
+// once witnesses of the given sizes are added.
+func (self *Transaction) MinimumNetworkFee(policy *FeePolicy, witnessSizes ...int) (Fixed8, error) {
+	size, err := self.EstimateSize(witnessSizes...)
+	if err != nil {
+		return Fixed8{}, err
+	}
+	return policy.MinimumFee(size)
+}
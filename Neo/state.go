@@ -0,0 +1,102 @@
+package Neo
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/neo-thinsdk-go/utils"
+)
+
+// maxVoteCandidates is the number of candidates one account may vote for.
+const maxVoteCandidates = 1024
+
+func NewStateTransData(descriptors []StateDescriptor) *StateTransData {
+	return &StateTransData{descriptors: append([]StateDescriptor(nil), descriptors...)}
+}
+
+func (self *StateTransData) Descriptors() []StateDescriptor {
+	return append([]StateDescriptor(nil), self.descriptors...)
+}
+
+// NewVoteDescriptor sets the candidates account votes for. An empty list
+// withdraws its votes.
+func NewVoteDescriptor(account UInt160, candidates [][]byte) (StateDescriptor, error) {
+	if len(candidates) > maxVoteCandidates {
+		return StateDescriptor{}, fmt.Errorf("%d candidates, at most %d allowed", len(candidates), maxVoteCandidates)
+	}
+	buf := &bytes.Buffer{}
+	writer := utils.NewBinaryWriter(buf)
+	writer.WriteVarInt(uint64(len(candidates)))
+	for _, candidate := range candidates {
+		key, err := compressPublicKey(candidate)
+		if err != nil {
+			return StateDescriptor{}, err
+		}
+		writer.WriteBytes(key)
+	}
+	return StateDescriptor{
+		descType: StateTypeAccount,
+		key:      account.Bytes(),
+		field:    "Votes",
+		value:    buf.Bytes(),
+	}, nil
+}
+
+// NewValidatorDescriptor registers publicKey as a consensus candidate, or
+// withdraws the registration when registered is false.
+func NewValidatorDescriptor(publicKey []byte, registered bool) (StateDescriptor, error) {
+	key, err := compressPublicKey(publicKey)
+	if err != nil {
+		return StateDescriptor{}, err
+	}
+	value := []byte{0}
+	if registered {
+		value[0] = 1
+	}
+	return StateDescriptor{
+		descType: StateTypeValidator,
+		key:      key,
+		field:    "Registered",
+		value:    value,
+	}, nil
+}
+
+func (self *StateDescriptor) Type() byte {
+	return self.descType
+}
+
+func (self *StateDescriptor) Key() []byte {
+	return append([]byte(nil), self.key...)
+}
+
+func (self *StateDescriptor) Field() string {
+	return self.field
+}
+
+func (self *StateDescriptor) Value() []byte {
+	return append([]byte(nil), self.value...)
+}
+
+// NewVoteBuilder starts a StateTransaction in which account votes for
+// candidates. Sign it with the key of account.
+func NewVoteBuilder(account UInt160, candidates [][]byte) *TransactionBuilder {
+	tb := NewTransactionBuilder(StateTransaction)
+	desc, err := NewVoteDescriptor(account, candidates)
+	if err != nil {
+		return tb.fail(err)
+	}
+	return tb.SetExtData(NewStateTransData([]StateDescriptor{desc}))
+}
+
+// NewValidatorRegistrationBuilder starts a StateTransaction registering
+// publicKey as a validator. Registration burns a system fee of 1000 GAS,
+// so GAS utxos and a change address have to be added before Build. Sign
+// it with the key of publicKey.
+func NewValidatorRegistrationBuilder(publicKey []byte) *TransactionBuilder {
+	tb := NewTransactionBuilder(StateTransaction)
+	desc, err := NewValidatorDescriptor(publicKey, true)
+	if err != nil {
+		return tb.fail(err)
+	}
+	return tb.SetExtData(NewStateTransData([]StateDescriptor{desc}))
+}
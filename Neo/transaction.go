@@ -2,12 +2,12 @@ package Neo
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"github.com/neo-thinsdk-go/utils"
-	"encoding/binary"
 	"crypto/ecdsa"
 	"math/big"
-	"github.com/neo-thinsdk-go/simplejson"
-	//"fmt"
+	"fmt"
 )
 
 const (
@@ -17,6 +17,7 @@ const (
 	EnrollmentTransaction byte = 0x20
 	RegisterTransaction byte = 0x40
 	ContractTransaction byte = 0x80
+	StateTransaction byte = 0x90
 	PublishTransaction byte = 0xd0
 	InvocationTransaction byte = 0xd1
 )
@@ -88,31 +89,55 @@ type Attribute struct {
 	data []byte
 }
 
-const D uint64 = 100000000
+type TransactionOutput struct {
+	assetId UInt256
+	value Fixed8
+	scriptHash UInt160
+}
 
-type Fixed8 struct {
-	value uint64
+func NewTransactionOutput(assetId UInt256, value Fixed8, scriptHash UInt160) TransactionOutput {
+	return TransactionOutput{assetId: assetId, value: value, scriptHash: scriptHash}
 }
 
-type TransactionOutput struct {
-	assetId []byte
-	value Fixed8
-	toAddress []byte
+func (self *TransactionOutput) AssetId() UInt256 {
+	return self.assetId
 }
 
+func (self *TransactionOutput) Value() Fixed8 {
+	return self.value
+}
+
+func (self *TransactionOutput) ScriptHash() UInt160 {
+	return self.scriptHash
+}
+
+// TransactionInput references output index of the transaction with the
+// given hash.
 type TransactionInput struct {
-	hash []byte
+	hash UInt256
 	index uint16
 }
 
+func NewTransactionInput(hash UInt256, index uint16) TransactionInput {
+	return TransactionInput{hash: hash, index: index}
+}
+
+func (self *TransactionInput) Hash() UInt256 {
+	return self.hash
+}
+
+func (self *TransactionInput) Index() uint16 {
+	return self.index
+}
+
 type Witness struct {
 	InvocationScript []byte
 	VerificationScript []byte
 }
 
 type IExtData interface {
-	Serialize(tx *Transaction, buf *bytes.Buffer)
-	Deserialize(tx *Transaction, buf *bytes.Buffer)
+	Serialize(tx *Transaction, writer *utils.BinaryWriter)
+	Deserialize(tx *Transaction, reader *utils.BinaryReader)
 }
 
 type InvokeTransData struct {
@@ -120,27 +145,109 @@ type InvokeTransData struct {
 	gas Fixed8
 }
 
-func (self *InvokeTransData) Serialize(tx *Transaction, buf *bytes.Buffer) {
-	length := len(self.script)
-	utils.WriteVarInt(buf, uint64(length))
-	buf.Write(self.script)
+func NewInvokeTransData(script []byte, gas Fixed8) *InvokeTransData {
+	return &InvokeTransData{script: script, gas: gas}
+}
+
+func (self *InvokeTransData) Serialize(tx *Transaction, writer *utils.BinaryWriter) {
+	writer.WriteVarBytes(self.script)
 	if tx.version >= 1 {
-		data := make([]byte, 8)
-		binary.LittleEndian.PutUint64(data, self.gas.value)
-		buf.Write(data)
+		writer.WriteInt64(self.gas.value)
 	}
 }
 
-func (self *InvokeTransData) Deserialize(tx *Transaction, buf *bytes.Buffer) {
-	length := utils.ReadVarInt(buf, 65535)
-	data := make([]byte, length)
-	buf.Read(data)
-	self.script = data
+func (self *InvokeTransData) Deserialize(tx *Transaction, reader *utils.BinaryReader) {
+	if tx.version > 1 {
+		reader.SetErr(fmt.Errorf("%w: invocation version %d", ErrBadFormat, tx.version))
+		return
+	}
+	self.script = reader.ReadVarBytes(65536)
 	if tx.version >= 1 {
-		value := make([]byte, 8)
-		buf.Read(value)
-		self.gas.value = binary.LittleEndian.Uint64(value)
+		self.gas.value = reader.ReadInt64()
 	}
+	if reader.Err() != nil {
+		return
+	}
+	if len(self.script) == 0 {
+		reader.SetErr(fmt.Errorf("%w: empty invocation script", ErrBadFormat))
+	} else if self.gas.value < 0 {
+		reader.SetErr(fmt.Errorf("%w: negative invocation gas", ErrBadFormat))
+	}
+}
+
+func (self *TransactionInput) Serialize(writer *utils.BinaryWriter) {
+	writer.WriteBytes(self.hash[:])
+	writer.WriteUint16(self.index)
+}
+
+func (self *TransactionInput) Deserialize(reader *utils.BinaryReader) {
+	reader.ReadFull(self.hash[:])
+	self.index = reader.ReadUint16()
+}
+
+func (self *TransactionOutput) Serialize(writer *utils.BinaryWriter) {
+	writer.WriteBytes(self.assetId[:])
+	writer.WriteInt64(self.value.value)
+	writer.WriteBytes(self.scriptHash[:])
+}
+
+func (self *TransactionOutput) Deserialize(reader *utils.BinaryReader) {
+	reader.ReadFull(self.assetId[:])
+	self.value.value = reader.ReadInt64()
+	reader.ReadFull(self.scriptHash[:])
+}
+
+func (self *Attribute) Serialize(writer *utils.BinaryWriter) {
+	if err := checkAttribute(self.usage, self.data); err != nil {
+		writer.SetErr(err)
+		return
+	}
+	usage := self.usage
+	writer.WriteUint8(usage)
+
+	if usage == ECDH02 || usage == ECDH03 {
+		writer.WriteBytes(self.data[1:])
+	} else if usage == DescriptionUrl {
+		writer.WriteUint8(uint8(len(self.data)))
+		writer.WriteBytes(self.data)
+	} else if usage == Description || usage >= Remark {
+		writer.WriteVarBytes(self.data)
+	} else {
+		writer.WriteBytes(self.data)
+	}
+}
+
+func (self *Attribute) Deserialize(reader *utils.BinaryReader) {
+	usage := reader.ReadUint8()
+	if reader.Err() != nil {
+		return
+	}
+	self.usage = usage
+
+	if usage == ContractHash || usage == Vote || (usage >= Hash1 && usage <= Hash15) {
+		self.data = reader.ReadBytes(32)
+	} else if usage == ECDH02 || usage == ECDH03 {
+		self.data = append([]byte{usage}, reader.ReadBytes(32)...)
+	} else if usage == Script {
+		self.data = reader.ReadBytes(20)
+	} else if usage == DescriptionUrl {
+		length := reader.ReadUint8()
+		self.data = reader.ReadBytes(int(length))
+	} else if usage == Description || usage >= Remark {
+		self.data = reader.ReadVarBytes(65535)
+	} else {
+		reader.SetErr(fmt.Errorf("%w: unknown usage 0x%02x", ErrBadAttribute, usage))
+	}
+}
+
+func (self *Witness) Serialize(writer *utils.BinaryWriter) {
+	writer.WriteVarBytes(self.InvocationScript)
+	writer.WriteVarBytes(self.VerificationScript)
+}
+
+func (self *Witness) Deserialize(reader *utils.BinaryReader) {
+	self.InvocationScript = reader.ReadVarBytes(65536)
+	self.VerificationScript = reader.ReadVarBytes(65536)
 }
 
 func (self *Witness)GetAddress() string  {
@@ -177,234 +284,324 @@ type Transaction struct {
 	outputs []TransactionOutput
 	witnesses []Witness
 	extdata IExtData
+	hash []byte
+	networkFee Fixed8
 }
 
-func (self *Transaction)GetMessage() ([]byte, bool)  {
-	buf := &bytes.Buffer{}
-	self.SerializeUnsigned(buf)
+func (self *Transaction)Type() byte  {
+	return self.txtype
+}
 
-	return buf.Bytes(), true
+func (self *Transaction)Version() byte  {
+	return self.version
 }
 
-func (self *Transaction)GetRawData() ([]byte, bool)  {
-	buf := &bytes.Buffer{}
-	self.Serialize(buf)
-	return buf.Bytes(), true
+// ExtData returns the type specific data, such as *StateTransData for a
+// StateTransaction, or nil for types without any.
+func (self *Transaction)ExtData() IExtData  {
+	return self.extdata
+}
+
+func (self *Transaction)Inputs() []TransactionInput  {
+	return append([]TransactionInput(nil), self.inputs...)
+}
+
+func (self *Transaction)Outputs() []TransactionOutput  {
+	return append([]TransactionOutput(nil), self.outputs...)
 }
 
-func (self *Transaction)GetHash() ([]byte, bool)  {
+func (self *Transaction)GetMessage() ([]byte, error)  {
 	buf := &bytes.Buffer{}
-	self.Serialize(buf)
-	return buf.Bytes(), true
+	if err := self.SerializeUnsigned(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
-func (self *Transaction)AddWitness(signData []byte, pubkey *ecdsa.PublicKey, addrs string )  {
+func (self *Transaction)GetRawData() ([]byte, error)  {
 	buf := &bytes.Buffer{}
-	self.SerializeUnsigned(buf)
+	if err := self.Serialize(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GetHash returns the transaction hash, the double SHA256 of the unsigned
+// message. The result is cached until the transaction content changes.
+func (self *Transaction)GetHash() ([]byte, error)  {
+	if self.hash == nil {
+		msg, err := self.GetMessage()
+		if err != nil {
+			return nil, err
+		}
+		self.hash = hash256(msg)
+	}
+	hash := make([]byte, len(self.hash))
+	copy(hash, self.hash)
+	return hash, nil
+}
 
-	data := buf.Bytes()
+// GetTxid returns the transaction id as shown by neo-cli: the hash in
+// reversed byte order, hex encoded with a 0x prefix.
+func (self *Transaction)GetTxid() (string, error)  {
+	hash, err := self.GetHash()
+	if err != nil {
+		return "", err
+	}
+	return "0x" + utils.ToHexString(utils.BytesReverse(hash)), nil
+}
+
+// GetSystemFee returns the GAS the transaction burns as system fee under
+// the SystemFees table.
+func (self *Transaction)GetSystemFee() Fixed8  {
+	fee := Fixed8{value: SystemFees[self.txtype] * D}
+	switch self.txtype {
+	case InvocationTransaction:
+		// version 0 invocations carry no gas
+		if extdata, ok := self.extdata.(*InvokeTransData); ok && self.version >= 1 {
+			return extdata.gas
+		}
+		return Fixed8{}
+	case IssueTransaction:
+		if self.version >= 1 {
+			return Fixed8{}
+		}
+		for _, output := range self.outputs {
+			if output.assetId != NeoAssetHash && output.assetId != GasAssetHash {
+				return fee
+			}
+		}
+		return Fixed8{}
+	case RegisterTransaction:
+		if extdata, ok := self.extdata.(*RegisterTransData); ok {
+			if extdata.assetType == AssetGoverningToken || extdata.assetType == AssetUtilityToken {
+				return Fixed8{}
+			}
+		}
+	case StateTransaction:
+		var total int64
+		if extdata, ok := self.extdata.(*StateTransData); ok {
+			for _, desc := range extdata.descriptors {
+				if desc.descType == StateTypeValidator && desc.field == "Registered" && hasNonZero(desc.value) {
+					total += validatorRegistrationFee
+				}
+			}
+		}
+		return Fixed8{value: total * D}
+	}
+	return fee
+}
+
+func hasNonZero(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (self *Transaction)invalidateHash()  {
+	self.hash = nil
+}
+
+func (self *Transaction)addInput(hash UInt256, index uint16)  {
+	self.inputs = append(self.inputs, TransactionInput{hash: hash, index: index})
+	self.invalidateHash()
+}
+
+func (self *Transaction)addOutput(output TransactionOutput)  {
+	self.outputs = append(self.outputs, output)
+	self.invalidateHash()
+}
+
+func (self *Transaction)addAttribute(attribute Attribute)  {
+	self.attributes = append(self.attributes, attribute)
+	self.invalidateHash()
+}
+
+func (self *Transaction)setExtData(extdata IExtData)  {
+	self.extdata = extdata
+	self.invalidateHash()
+}
+
+func (self *Transaction)AddWitness(signData []byte, pubkey *ecdsa.PublicKey, addrs string ) error {
+	data, err := self.GetMessage()
+	if err != nil {
+		return err
+	}
 
 	bSign := Verify(data, signData, pubkey)
 	if !bSign {
-		panic("runtime error: verify error")
+		return ErrBadSignature
 	}
 
 	addr := getAddressFromPublicKey(pubkey)
 	if addr != addrs {
-		panic("runtime error: address error")
+		return fmt.Errorf("%w: %s is not %s", ErrAddressMismatch, addrs, addr)
 	}
 
 	vscript := getScriptFromPublicKey(pubkey)
 	sb := &ScriptBuilder{}
 	sb.EmitPushBytes(signData)
 
-	iscript := sb.toBytes()
+	iscript, err := sb.ToArray()
+	if err != nil {
+		return err
+	}
 	self.AddWitnessScript(vscript, iscript)
+	return nil
 }
 
+// AddWitnessScript adds a witness, keeping the witnesses sorted by the
+// script hash they verify as nodes require. It reports false when a
+// witness for the same script is already present.
 func (self *Transaction)AddWitnessScript(script []byte, iscript []byte) bool {
-	//scripthash := getScriptHashFromScript(script)
 	newwit := Witness{}
 	newwit.VerificationScript = script
 	newwit.InvocationScript = iscript
-	size := len(self.witnesses)
-	for i := 0; i < size; i++ {
-		tmpwit := self.witnesses[i]
-		tmpAddr := tmpwit.GetAddress()
-		newAddr := newwit.GetAddress()
-		if tmpAddr == newAddr {
+	newHash := UInt160FromScript(script)
+	pos := len(self.witnesses)
+	for i := range self.witnesses {
+		cmp := UInt160FromScript(self.witnesses[i].VerificationScript).CompareTo(newHash)
+		if cmp == 0 {
 			return false
 		}
+		if cmp > 0 && pos == len(self.witnesses) {
+			pos = i
+		}
 	}
-	self.witnesses = append(self.witnesses, newwit)
+	self.witnesses = append(self.witnesses, Witness{})
+	copy(self.witnesses[pos+1:], self.witnesses[pos:])
+	self.witnesses[pos] = newwit
 	return true
 }
 
-func (self *Transaction)SerializeUnsigned(buf *bytes.Buffer)  {
-	buf.WriteByte(uint8(self.txtype))
-	buf.WriteByte(self.version)
-	if self.txtype == ContractTransaction {
+// SerializeUnsigned writes the transaction without its witnesses, which
+// is the message that gets signed and hashed.
+func (self *Transaction)SerializeUnsigned(w io.Writer) error {
+	writer := utils.NewBinaryWriter(w)
+	self.SerializeUnsignedTo(writer)
+	return writer.Err()
+}
 
-	} else if self.txtype == InvocationTransaction {
-		self.extdata.Serialize(self, buf)
-	} else {
-		panic("runtime error: tx type error")
-	}
-
-	length := len(self.attributes)
-	utils.WriteVarInt(buf, uint64(length))
-
-	for i := 0; i < length; i++ {
-		attriData := self.attributes[i].data
-		usage := self.attributes[i].usage
-
-		if usage == ContractHash || usage == Vote || (usage >= Hash1 && usage <= Hash15) {
-			buf.Write(attriData[0:32])
-		} else if usage == ECDH02 || usage == ECDH03 {
-			buf.Write(attriData[1:33])
-		} else if usage == Script {
-			buf.Write(attriData[0:20])
-		} else if usage == DescriptionUrl {
-			size := len(attriData)
-			buf.WriteByte(uint8(size))
-			buf.Write(attriData[0:size])
-		} else if usage == Description || usage >= Remark {
-			size := len(attriData)
-			utils.WriteVarInt(buf, uint64(size))
-			buf.Write(attriData[0:size])
-		} else {
-			panic("runtime error: attribute type error")
+func (self *Transaction)SerializeUnsignedTo(writer *utils.BinaryWriter) {
+	writer.WriteUint8(self.txtype)
+	writer.WriteUint8(self.version)
+	empty, ok := newExtData(self.txtype)
+	if !ok {
+		writer.SetErr(fmt.Errorf("%w: 0x%02x", ErrUnknownTxType, self.txtype))
+		return
+	}
+	if empty != nil {
+		if self.extdata == nil {
+			writer.SetErr(ErrMissingExtData)
+			return
 		}
+		self.extdata.Serialize(self, writer)
 	}
 
-	countInputs := len(self.inputs)
-	utils.WriteVarInt(buf, uint64(countInputs))
-	for i := 0; i < countInputs; i++ {
-		input := self.inputs[i]
-		buf.Write(input.hash)
+	writer.WriteVarInt(uint64(len(self.attributes)))
+	for i := range self.attributes {
+		self.attributes[i].Serialize(writer)
+	}
 
-		data := make([]byte, 2)
-		binary.LittleEndian.PutUint16(data, uint16(input.index))
-		buf.Write(data)
+	writer.WriteVarInt(uint64(len(self.inputs)))
+	for i := range self.inputs {
+		self.inputs[i].Serialize(writer)
 	}
 
-	countOutputs := len(self.outputs)
-	utils.WriteVarInt(buf, uint64(countOutputs))
-	for i := 0; i < countOutputs; i++ {
-		output := self.outputs[i]
-		buf.Write(output.assetId)
-		data := make([]byte, 8)
-		binary.LittleEndian.PutUint64(data, uint64(output.value.value))
-		buf.Write(data)
-		buf.Write(output.toAddress)
+	writer.WriteVarInt(uint64(len(self.outputs)))
+	for i := range self.outputs {
+		self.outputs[i].Serialize(writer)
 	}
 }
 
-func (self *Transaction)Serialize(buf *bytes.Buffer)  {
-	self.SerializeUnsigned(buf)
+func (self *Transaction)Serialize(w io.Writer) error {
+	writer := utils.NewBinaryWriter(w)
+	self.SerializeTo(writer)
+	return writer.Err()
+}
 
-	length := len(self.witnesses)
-	utils.WriteVarInt(buf, uint64(length))
+func (self *Transaction)SerializeTo(writer *utils.BinaryWriter) {
+	self.SerializeUnsignedTo(writer)
 
-	for i := 0; i < length; i++ {
-		_witness := self.witnesses[i]
-		utils.WriteVarInt(buf, uint64(len(_witness.InvocationScript)))
-		buf.Write(_witness.InvocationScript)
-		utils.WriteVarInt(buf, uint64(len(_witness.VerificationScript)))
-		buf.Write(_witness.VerificationScript)
+	writer.WriteVarInt(uint64(len(self.witnesses)))
+	for i := range self.witnesses {
+		self.witnesses[i].Serialize(writer)
 	}
 }
 
-func (self *Transaction)Deserialize(buf *bytes.Buffer)  {
-	txtype, _ := buf.ReadByte()
-	self.txtype = uint8(txtype)
-	version, _ := buf.ReadByte()
-	self.version = uint8(version)
+// Deserialize reads one complete transaction. Truncated data is reported
+// as ErrTruncatedInput.
+func (self *Transaction)Deserialize(r io.Reader) error {
+	reader := utils.NewBinaryReader(r)
+	self.DeserializeFrom(reader)
+	err := reader.Err()
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %v", ErrTruncatedInput, err)
+	}
+	return err
+}
 
-	if txtype == ContractTransaction {
-		self.extdata = nil
-	} else if txtype == InvocationTransaction {
-		self.extdata = &InvokeTransData{}
-	} else {
-		panic("runtime error: tx type error")
+// DeserializeFrom reads one transaction from reader, leaving any error in
+// the reader. It is meant for decoding several transactions from one
+// stream, such as a block.
+func (self *Transaction)DeserializeFrom(reader *utils.BinaryReader) {
+	self.invalidateHash()
+	self.attributes = nil
+	self.inputs = nil
+	self.outputs = nil
+	self.witnesses = nil
+
+	self.txtype = reader.ReadUint8()
+	self.version = reader.ReadUint8()
+	if reader.Err() != nil {
+		return
+	}
+
+	extdata, ok := newExtData(self.txtype)
+	if !ok {
+		reader.SetErr(fmt.Errorf("%w: 0x%02x", ErrUnknownTxType, self.txtype))
+		return
 	}
+	self.extdata = extdata
 	if self.extdata != nil {
-		self.extdata.Deserialize(self, buf)
-	}
-
-	countAttri := utils.ReadVarInt(buf, 65535)
-	if countAttri > 0 {
-		self.attributes = make([]Attribute, countAttri)
-	}
-	var i uint64 = 0;
-	for ; i < countAttri; i++ {
-		usage, _ := buf.ReadByte()
-		self.attributes[i].usage = usage
-
-		if usage == ContractHash || usage == Vote || (usage >= Hash1 && usage <= Hash15) {
-			attriData := make([]byte, 32)
-			buf.Read(attriData)
-			self.attributes[i].data = attriData
-		} else if usage == ECDH02 || usage == ECDH03 {
-			attriData := make([]byte, 33)
-			attriData[0] = usage
-			buf.Read(attriData[1:])
-			self.attributes[i].data = attriData
-		} else if usage == Script {
-			attriData := make([]byte, 20)
-			buf.Read(attriData)
-			self.attributes[i].data = attriData
-		} else if usage == DescriptionUrl {
-			length, _ := buf.ReadByte()
-			attriData := make([]byte, length)
-			buf.Read(attriData)
-			self.attributes[i].data = attriData
-
-		} else if usage == Description || usage >= Remark {
-			length := utils.ReadVarInt(buf, 65535)
-			attriData := make([]byte, length)
-			buf.Read(attriData)
-			self.attributes[i].data = attriData
-		} else {
-			panic("runtime error: attribute type error")
-		}
+		self.extdata.Deserialize(self, reader)
 	}
 
-	countInputs := utils.ReadVarInt(buf, 65535)
-	if countInputs > 0 {
-		self.inputs = make([]TransactionInput, countInputs)
+	countAttri := reader.ReadVarInt(16)
+	for i := uint64(0); i < countAttri && reader.Err() == nil; i++ {
+		attribute := Attribute{}
+		attribute.Deserialize(reader)
+		self.attributes = append(self.attributes, attribute)
 	}
-	i = 0
-	for ; i < countInputs; i++ {
-		hash := make([]byte, 32)
-		buf.Read(hash)
-		self.inputs[i].hash = hash
 
-		indexBytes := make([]byte, 2)
-		buf.Read(indexBytes)
-		self.inputs[i].index = binary.LittleEndian.Uint16(indexBytes)
+	countInputs := reader.ReadVarInt(65535)
+	for i := uint64(0); i < countInputs && reader.Err() == nil; i++ {
+		input := TransactionInput{}
+		input.Deserialize(reader)
+		self.inputs = append(self.inputs, input)
 	}
 
-	countOutputs := utils.ReadVarInt(buf, 65535)
-	if countOutputs > 0 {
-		self.outputs = make([]TransactionOutput, countOutputs)
+	countOutputs := reader.ReadVarInt(65535)
+	for i := uint64(0); i < countOutputs && reader.Err() == nil; i++ {
+		output := TransactionOutput{}
+		output.Deserialize(reader)
+		self.outputs = append(self.outputs, output)
 	}
-	i = 0
-	for ; i < countOutputs; i++ {
-		assetId := make([]byte, 32)
-		buf.Read(assetId)
-		self.outputs[i].assetId = assetId
-		valueBytes := make([]byte, 8)
-		buf.Read(valueBytes)
-		self.outputs[i].value.value = binary.LittleEndian.Uint64(valueBytes)
-		toAddress := make([]byte, 20)
-		self.outputs[i].toAddress = toAddress
+
+	countWitnesses := reader.ReadVarInt(65535)
+	for i := uint64(0); i < countWitnesses && reader.Err() == nil; i++ {
+		witness := Witness{}
+		witness.Deserialize(reader)
+		self.witnesses = append(self.witnesses, witness)
 	}
 }
 
 type Utxo struct {
-	Hash string
-	Value uint64
+	Hash UInt256
+	Value Fixed8
 	N uint16
 }
 
@@ -415,150 +612,96 @@ type CreateSignParams struct {
 	From string
 	To string
 	AssetId string
-	Value uint64
+	Value Fixed8
 	Data []byte
 	Utxos []Utxo
 }
 
 func CreateContractTransaction(params *CreateSignParams) (string, bool) {
-	tx := &Transaction{}
-	tx.txtype = ContractTransaction
-	tx.version = params.Version
-
-	var sum uint64 = 0
-	size := len(params.Utxos)
-	if size > 0 {
-		tx.inputs = make([]TransactionInput, size)
-	}
-	for i := 0; i < size; i++ {
-		txid, _ := utils.ToBytes(params.Utxos[i].Hash)
-		tx.inputs[i].hash = utils.BytesReverse(txid)
-		tx.inputs[i].index = params.Utxos[i].N
-		sum += params.Utxos[i].Value
-	}
-
-	value := params.Value
-	toAddress := params.To
-	if sum < value {
+	assetId, err := ParseUInt256(params.AssetId)
+	if err != nil {
 		return "", false
 	}
-	assetId := params.AssetId
-	output := TransactionOutput{}
-	vAssetId, _ := utils.ToBytes(assetId)
-	vAssetId = utils.BytesReverse(vAssetId)
-	output.assetId = vAssetId
-	output.value.value = value
-	pubkeyhash, _ := getPublicKeyHashFromAddress(toAddress)
-	output.toAddress = pubkeyhash
-	tx.outputs = append(tx.outputs, output)
-
-	fromAddress := params.From
-	left := sum - value
-	if left > 0 {
-		output2 := TransactionOutput{}
-		output2.assetId = vAssetId
-		output2.value.value = left
-		pkh, _ := getPublicKeyHashFromAddress(fromAddress)
-		output2.toAddress = pkh
-		tx.outputs = append(tx.outputs, output)
-	}
-
-	unsignedData, _ := tx.GetMessage()
-	privKey := &ecdsa.PrivateKey{}
-	FromWIF(privKey, params.PriKey)
-
-	signature, err := Sign(unsignedData, privKey)
+	tb := NewTransactionBuilder(ContractTransaction).Version(params.Version)
+	tb.AddOutput(assetId, params.Value, params.To)
+	tb.SelectInputs(&CoinSelection{Selector: LargestFirst{}}, map[UInt256][]Utxo{assetId: params.Utxos})
+	tb.SetChangeAddress(params.From)
+
+	tx, err := tb.Build()
 	if err != nil {
 		return "", false
 	}
-
-	pubKey := privKey.PublicKey
-	tx.AddWitness(signature, &pubKey, fromAddress)
-
-	rawData, _ := tx.GetRawData()
-	raw := utils.ToHexString(rawData)
-
-	return raw, true
+	return signWithWIF(tx, params.PriKey, params.From)
 }
 
 func GetNep5Transfer(scriptAddress string, from, to string, num big.Int) ([]byte, bool) {
+	scriptHash, err := ParseUInt160(scriptAddress)
+	if err != nil {
+		return nil, false
+	}
+	fromHash, err := UInt160FromAddress(from)
+	if err != nil {
+		return nil, false
+	}
+	toHash, err := UInt160FromAddress(to)
+	if err != nil {
+		return nil, false
+	}
 	sb := &ScriptBuilder{}
-	assetId, _ := utils.ToBytes(scriptAddress)
-	assetId = utils.BytesReverse(assetId)
-
-	jsonData := make(map[string]interface{})
-
-	fromParam := "(address)" + from;
-	jsonData["from"] = fromParam
-
-	toParam := "(address)" + to;
-	jsonData["to"] = toParam
-
-	strInt := num.String()
-	numParam := "(integer)" + strInt
-	jsonData["num"] = numParam
-
-	paramList := &simplejson.Json{Data:jsonData}
-
-	sb.EmitParamJson(paramList)
-	sb.EmitPushString("transfer")
-	sb.EmitAppCall(assetId, false)
+	err = sb.EmitContractCall(scriptHash, "transfer",
+		NewHash160Parameter(fromHash), NewHash160Parameter(toHash), NewIntegerParameter(&num))
+	if err != nil {
+		return nil, false
+	}
 
-	rawdata := sb.toBytes()
+	rawdata, err := sb.ToArray()
+	if err != nil {
+		return nil, false
+	}
 	return rawdata, true
 }
 
 func CreateInvocationTransaction(params *CreateSignParams) (string, bool) {
-	tx := &Transaction{}
-	tx.txtype = InvocationTransaction
-	tx.version = params.Version
-
-	var sum uint64 = 0
-	size := len(params.Utxos)
-	if size > 0 {
-		tx.inputs = make([]TransactionInput, size)
+	assetId, err := ParseUInt256(params.AssetId)
+	if err != nil {
+		return "", false
 	}
-	for i := 0; i < size; i++ {
-		txid, _ := utils.ToBytes(params.Utxos[i].Hash)
-		tx.inputs[i].hash = utils.BytesReverse(txid)
-		tx.inputs[i].index = params.Utxos[i].N
-		sum += params.Utxos[i].Value
+	tb := NewTransactionBuilder(InvocationTransaction).Version(params.Version)
+	for _, utxo := range params.Utxos {
+		tb.AddUtxo(assetId, utxo)
 	}
+	// what the invocation gas leaves of the inputs goes to params.To
+	tb.SetChangeAddress(params.To)
+	gas := Fixed8{}
+	if params.Version >= 1 {
+		gas = Fixed8{value: D}
+	}
+	tb.SetExtData(NewInvokeTransData(params.Data, gas))
 
-	toAddress := params.To
-	if sum <= 0 {
+	tx, err := tb.Build()
+	if err != nil {
 		return "", false
 	}
-	assetId := params.AssetId
-	output := TransactionOutput{}
-	vAssetId, _ := utils.ToBytes(assetId)
-	vAssetId = utils.BytesReverse(vAssetId)
-	output.assetId = vAssetId
-	output.value.value = sum
-	pubkeyhash, _ := getPublicKeyHashFromAddress(toAddress)
-	output.toAddress = pubkeyhash
-	tx.outputs = append(tx.outputs, output)
-
-	fromAddress := params.From
-	extdata := &InvokeTransData{}
-	extdata.script = params.Data
-	extdata.gas.value = 100000000;
-	tx.extdata = extdata
-
-	unsignedData, _ := tx.GetMessage()
-	privKey := &ecdsa.PrivateKey{}
-	FromWIF(privKey, params.PriKey)
-
-	signature, err := Sign(unsignedData, privKey)
+	return signWithWIF(tx, params.PriKey, params.From)
+}
+
+func signWithWIF(tx *Transaction, wif string, from string) (string, bool) {
+	privKey, err := privateKeyFromWIF(wif)
 	if err != nil {
 		return "", false
 	}
+	if getAddressFromPublicKey(&privKey.PublicKey) != from {
+		return "", false
+	}
+	if err := tx.Sign(privKey); err != nil {
+		return "", false
+	}
 
-	pubKey := privKey.PublicKey
-	tx.AddWitness(signature, &pubKey, fromAddress)
-
-	rawData, _ := tx.GetRawData()
+	rawData, err := tx.GetRawData()
+	if err != nil {
+		return "", false
+	}
 	raw := utils.ToHexString(rawData)
 
 	return raw, true
-}
\ No newline at end of file
+}
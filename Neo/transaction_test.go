@@ -0,0 +1,145 @@
+package Neo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neo-thinsdk-go/utils"
+)
+
+// Transactions of the mainnet genesis block, whose hashes are the well
+// known genesis txid and the NEO and GAS asset ids.
+var genesisVectors = []struct {
+	name   string
+	raw    string
+	txid   string
+	txtype byte
+}{
+	{
+		name:   "miner",
+		raw:    "00001dac2b7c00000000",
+		txid:   "0xfb5bd72b2d6792d75dc2f1084ffa9e9f70ca85543c717a6b13d9959b452a57d6",
+		txtype: MinerTransaction,
+	},
+	{
+		name: "register NEO",
+		raw: "400000455b7b226c616e67223a227a682d434e222c226e616d65223a22e5b08fe89a81e882a1227d2c7b226c616e6722" +
+			"3a22656e222c226e616d65223a22416e745368617265227d5d0000c16ff28623000000da1745e9b549bd0bfa1a5699" +
+			"71c77eba30cd5a4b00000000",
+		txid:   "0x" + NeoAssetId,
+		txtype: RegisterTransaction,
+	},
+	{
+		name: "register GAS",
+		raw: "400001445b7b226c616e67223a227a682d434e222c226e616d65223a22e5b08fe89a81e5b881227d2c7b226c616e6722" +
+			"3a22656e222c226e616d65223a22416e74436f696e227d5d0000c16ff286230008009f7fd096d37ed2c0e3f7f0cfc924" +
+			"beef4ffceb6800000000",
+		txid:   "0x" + GasAssetId,
+		txtype: RegisterTransaction,
+	},
+}
+
+func decodeVector(t *testing.T, raw string) *Transaction {
+	t.Helper()
+	data, ok := utils.ToBytes(raw)
+	if !ok {
+		t.Fatalf("bad hex %q", raw)
+	}
+	tx := &Transaction{}
+	if err := tx.Deserialize(bytes.NewReader(data)); err != nil {
+		t.Fatalf("Deserialize: %v", err)
+	}
+	return tx
+}
+
+func serializeHex(t *testing.T, tx *Transaction) string {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	if err := tx.Serialize(buf); err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+	return utils.ToHexString(buf.Bytes())
+}
+
+func TestGenesisTransactions(t *testing.T) {
+	for _, v := range genesisVectors {
+		t.Run(v.name, func(t *testing.T) {
+			tx := decodeVector(t, v.raw)
+			if tx.Type() != v.txtype {
+				t.Errorf("type 0x%02x, want 0x%02x", tx.Type(), v.txtype)
+			}
+			txid, err := tx.GetTxid()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if txid != v.txid {
+				t.Errorf("txid %s, want %s", txid, v.txid)
+			}
+			if got := serializeHex(t, tx); got != v.raw {
+				t.Errorf("serialized %s, want %s", got, v.raw)
+			}
+
+			data, err := json.Marshal(tx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded := &Transaction{}
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("UnmarshalJSON %s: %v", data, err)
+			}
+			if got := serializeHex(t, decoded); got != v.raw {
+				t.Errorf("after JSON %s, want %s", got, v.raw)
+			}
+		})
+	}
+}
+
+func TestGenesisAssets(t *testing.T) {
+	for i, want := range []UInt256{NeoAssetHash, GasAssetHash} {
+		tx := decodeVector(t, genesisVectors[i+1].raw)
+		assetId, err := tx.GetAssetId()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if assetId != want {
+			t.Errorf("asset id %s, want %s", assetId, want)
+		}
+		data := tx.ExtData().(*RegisterTransData)
+		if data.Amount().String() != "100000000" {
+			t.Errorf("amount %s", data.Amount())
+		}
+		if fee := tx.GetSystemFee(); fee.Sign() != 0 {
+			t.Errorf("system fee %s for a genesis asset", fee)
+		}
+	}
+}
+
+func TestDeserializeRejectsBadFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"unknown state descriptor", "9000" + "01" + "35" + "0101" + "0141" + "0100" + "00000000"},
+		{"account descriptor with short key", "9000" + "01" + "40" + "0101" + "05566f746573" + "0100" + "00000000"},
+		{"validator descriptor with bad field", "9000" + "01" + "48" + "2102" + strings.Repeat("00", 32) + "05566f746573" + "0101" + "00000000"},
+		{"empty invocation script", "d100" + "00" + "00000000"},
+		{"invocation version 2", "d102" + "0151" + "0000000000000000" + "00000000"},
+		{"negative invocation gas", "d101" + "0151" + "ffffffffffffffff" + "00000000"},
+		{"claim without claims", "0200" + "00" + "00000000"},
+		{"claim version 1", "0201" + "01" + strings.Repeat("00", 34) + "00000000"},
+		{"enrollment version 1", "2001" + "02" + strings.Repeat("00", 32) + "00000000"},
+		{"register version 1", genesisVectors[1].raw[:2] + "01" + genesisVectors[1].raw[4:]},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, _ := utils.ToBytes(test.raw)
+			err := (&Transaction{}).Deserialize(bytes.NewReader(data))
+			if !errors.Is(err, ErrBadFormat) {
+				t.Errorf("got %v, want ErrBadFormat", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package Neo
+
+import (
+	"fmt"
+
+	"github.com/neo-thinsdk-go/utils"
+)
+
+const (
+	StateTypeAccount   byte = 0x40
+	StateTypeValidator byte = 0x48
+)
+
+const (
+	AssetCreditFlag     byte = 0x40
+	AssetDutyFlag       byte = 0x80
+	AssetGoverningToken byte = 0x00
+	AssetUtilityToken   byte = 0x01
+	AssetCurrency       byte = 0x08
+	AssetShare          byte = AssetDutyFlag | 0x10
+	AssetInvoice        byte = AssetDutyFlag | 0x18
+	AssetToken          byte = AssetCreditFlag | 0x20
+)
+
+// Asset ids of NEO and GAS, as printed by neo-cli without the 0x prefix.
+const (
+	NeoAssetId = "c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b"
+	GasAssetId = "602c79718b16e442de58778e148d0b1084e3b2dffd5de6b7b16cee7969282de7"
+)
+
+// SystemFees holds the protocol system fee per transaction type in whole
+// GAS, as in the SystemFee section of the node's protocol.json. Private
+// chains with other settings can change it.
+var SystemFees = map[byte]int64{
+	EnrollmentTransaction: 1000,
+	IssueTransaction:      500,
+	PublishTransaction:    500,
+	RegisterTransaction:   10000,
+}
+
+// validatorRegistrationFee is the system fee of a StateTransaction
+// descriptor that registers a validator.
+const validatorRegistrationFee int64 = 1000
+
+// newExtData returns an empty extdata for txtype. Transaction types that
+// carry no extdata report nil together with ok.
+func newExtData(txtype byte) (IExtData, bool) {
+	switch txtype {
+	case ContractTransaction, IssueTransaction:
+		return nil, true
+	case MinerTransaction:
+		return &MinerTransData{}, true
+	case ClaimTransaction:
+		return &ClaimTransData{}, true
+	case EnrollmentTransaction:
+		return &EnrollmentTransData{}, true
+	case RegisterTransaction:
+		return &RegisterTransData{}, true
+	case StateTransaction:
+		return &StateTransData{}, true
+	case PublishTransaction:
+		return &PublishTransData{}, true
+	case InvocationTransaction:
+		return &InvokeTransData{}, true
+	}
+	return nil, false
+}
+
+// readECPoint reads an encoded public key, keeping the encoding as is:
+// 0x00 for infinity, 33 bytes compressed or 65 bytes uncompressed.
+func readECPoint(reader *utils.BinaryReader) []byte {
+	prefix := reader.ReadUint8()
+	if reader.Err() != nil {
+		return nil
+	}
+	var length int
+	switch prefix {
+	case 0x00:
+		length = 1
+	case 0x02, 0x03:
+		length = 33
+	case 0x04, 0x06, 0x07:
+		length = 65
+	default:
+		reader.SetErr(fmt.Errorf("%w: prefix 0x%02x", ErrBadPublicKey, prefix))
+		return nil
+	}
+	data := make([]byte, length)
+	data[0] = prefix
+	reader.ReadFull(data[1:])
+	return data
+}
+
+type MinerTransData struct {
+	nonce uint32
+}
+
+func (self *MinerTransData) Serialize(tx *Transaction, writer *utils.BinaryWriter) {
+	writer.WriteUint32(self.nonce)
+}
+
+func (self *MinerTransData) Deserialize(tx *Transaction, reader *utils.BinaryReader) {
+	self.nonce = reader.ReadUint32()
+}
+
+type ClaimTransData struct {
+	claims []TransactionInput
+}
+
+func (self *ClaimTransData) Serialize(tx *Transaction, writer *utils.BinaryWriter) {
+	writer.WriteVarInt(uint64(len(self.claims)))
+	for _, claim := range self.claims {
+		claim.Serialize(writer)
+	}
+}
+
+func (self *ClaimTransData) Deserialize(tx *Transaction, reader *utils.BinaryReader) {
+	if tx.version != 0 {
+		reader.SetErr(fmt.Errorf("%w: claim version %d", ErrBadFormat, tx.version))
+		return
+	}
+	count := reader.ReadVarInt(65535)
+	if reader.Err() == nil && count == 0 {
+		reader.SetErr(fmt.Errorf("%w: claim transaction without claims", ErrBadFormat))
+		return
+	}
+	self.claims = nil
+	for i := uint64(0); i < count && reader.Err() == nil; i++ {
+		claim := TransactionInput{}
+		claim.Deserialize(reader)
+		self.claims = append(self.claims, claim)
+	}
+}
+
+type EnrollmentTransData struct {
+	publicKey []byte
+}
+
+func (self *EnrollmentTransData) Serialize(tx *Transaction, writer *utils.BinaryWriter) {
+	writer.WriteBytes(self.publicKey)
+}
+
+func (self *EnrollmentTransData) Deserialize(tx *Transaction, reader *utils.BinaryReader) {
+	if tx.version != 0 {
+		reader.SetErr(fmt.Errorf("%w: enrollment version %d", ErrBadFormat, tx.version))
+		return
+	}
+	self.publicKey = readECPoint(reader)
+}
+
+type RegisterTransData struct {
+	assetType byte
+	name      string
+	amount    Fixed8
+	precision byte
+	owner     []byte
+	admin     UInt160
+}
+
+func (self *RegisterTransData) Serialize(tx *Transaction, writer *utils.BinaryWriter) {
+	writer.WriteUint8(self.assetType)
+	writer.WriteVarString(self.name)
+	writer.WriteInt64(self.amount.value)
+	writer.WriteUint8(self.precision)
+	writer.WriteBytes(self.owner)
+	writer.WriteBytes(self.admin[:])
+}
+
+func (self *RegisterTransData) Deserialize(tx *Transaction, reader *utils.BinaryReader) {
+	if tx.version != 0 {
+		reader.SetErr(fmt.Errorf("%w: register version %d", ErrBadFormat, tx.version))
+		return
+	}
+	self.assetType = reader.ReadUint8()
+	self.name = reader.ReadVarString(1024)
+	self.amount.value = reader.ReadInt64()
+	self.precision = reader.ReadUint8()
+	self.owner = readECPoint(reader)
+	reader.ReadFull(self.admin[:])
+}
+
+// StateDescriptor changes one field of an account or validator state.
+type StateDescriptor struct {
+	descType byte
+	key      []byte
+	field    string
+	value    []byte
+}
+
+type StateTransData struct {
+	descriptors []StateDescriptor
+}
+
+func (self *StateTransData) Serialize(tx *Transaction, writer *utils.BinaryWriter) {
+	writer.WriteVarInt(uint64(len(self.descriptors)))
+	for _, desc := range self.descriptors {
+		writer.WriteUint8(desc.descType)
+		writer.WriteVarBytes(desc.key)
+		writer.WriteVarString(desc.field)
+		writer.WriteVarBytes(desc.value)
+	}
+}
+
+func (self *StateTransData) Deserialize(tx *Transaction, reader *utils.BinaryReader) {
+	count := reader.ReadVarInt(16)
+	self.descriptors = make([]StateDescriptor, count)
+	for i := range self.descriptors {
+		desc := &self.descriptors[i]
+		desc.descType = reader.ReadUint8()
+		desc.key = reader.ReadVarBytes(100)
+		desc.field = reader.ReadVarString(32)
+		desc.value = reader.ReadVarBytes(65535)
+		if reader.Err() != nil {
+			return
+		}
+		switch {
+		case desc.descType == StateTypeAccount && len(desc.key) == 20 && desc.field == "Votes":
+		case desc.descType == StateTypeValidator && len(desc.key) == 33 && desc.field == "Registered":
+		default:
+			reader.SetErr(fmt.Errorf("%w: state descriptor 0x%02x with %d byte key and field %q",
+				ErrBadFormat, desc.descType, len(desc.key), desc.field))
+			return
+		}
+	}
+}
+
+type PublishTransData struct {
+	script        []byte
+	parameterList []byte
+	returnType    byte
+	needStorage   bool
+	name          string
+	codeVersion   string
+	author        string
+	email         string
+	description   string
+}
+
+func (self *PublishTransData) Serialize(tx *Transaction, writer *utils.BinaryWriter) {
+	writer.WriteVarBytes(self.script)
+	writer.WriteVarBytes(self.parameterList)
+	writer.WriteUint8(self.returnType)
+	if tx.version >= 1 {
+		writer.WriteBool(self.needStorage)
+	}
+	writer.WriteVarString(self.name)
+	writer.WriteVarString(self.codeVersion)
+	writer.WriteVarString(self.author)
+	writer.WriteVarString(self.email)
+	writer.WriteVarString(self.description)
+}
+
+func (self *PublishTransData) Deserialize(tx *Transaction, reader *utils.BinaryReader) {
+	self.script = reader.ReadVarBytes(0x1000000)
+	self.parameterList = reader.ReadVarBytes(255)
+	self.returnType = reader.ReadUint8()
+	if tx.version >= 1 {
+		self.needStorage = reader.ReadBool()
+	}
+	self.name = reader.ReadVarString(252)
+	self.codeVersion = reader.ReadVarString(252)
+	self.author = reader.ReadVarString(252)
+	self.email = reader.ReadVarString(252)
+	self.description = reader.ReadVarString(65536)
+}
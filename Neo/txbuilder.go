@@ -0,0 +1,312 @@
+package Neo
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+)
+
+// TransactionBuilder assembles an unsigned transaction step by step.
+// Every method returns the builder so calls can be chained; the first
+// error encountered is kept and reported by Build.
+type TransactionBuilder struct {
+	tx            *Transaction
+	err           error
+	assets        []UInt256
+	inSums        map[UInt256]Fixed8
+	outSums       map[UInt256]Fixed8
+	change        map[UInt256]UInt160
+	defaultChange *UInt160
+	networkFee    Fixed8
+	feePolicy     *FeePolicy
+	witnessSizes  []int
+	// set by SelectInputs so Build can pick more GAS when the fee grows
+	selection *CoinSelection
+	utxos     map[UInt256][]Utxo
+}
+
+func NewTransactionBuilder(txtype byte) *TransactionBuilder {
+	tb := &TransactionBuilder{}
+	tb.tx = &Transaction{txtype: txtype}
+	tb.inSums = make(map[UInt256]Fixed8)
+	tb.outSums = make(map[UInt256]Fixed8)
+	tb.change = make(map[UInt256]UInt160)
+	return tb
+}
+
+func (tb *TransactionBuilder) fail(err error) *TransactionBuilder {
+	if tb.err == nil {
+		tb.err = err
+	}
+	return tb
+}
+
+func (tb *TransactionBuilder) trackAsset(assetId UInt256) {
+	if _, ok := tb.inSums[assetId]; !ok {
+		if _, ok := tb.outSums[assetId]; !ok {
+			tb.assets = append(tb.assets, assetId)
+		}
+	}
+}
+
+func (tb *TransactionBuilder) Version(version byte) *TransactionBuilder {
+	tb.tx.version = version
+	return tb
+}
+
+// AddInput spends the given output without tracking its value, so it does
+// not take part in change calculation.
+func (tb *TransactionBuilder) AddInput(txid UInt256, index uint16) *TransactionBuilder {
+	tb.tx.addInput(txid, index)
+	return tb
+}
+
+// AddUtxo spends utxo, which holds value of assetId. Its value is counted
+// when the change outputs are computed.
+func (tb *TransactionBuilder) AddUtxo(assetId UInt256, utxo Utxo) *TransactionBuilder {
+	if utxo.Value.Sign() <= 0 {
+		return tb.fail(fmt.Errorf("%w: utxo value %s", ErrBadAmount, utxo.Value))
+	}
+	tb.AddInput(utxo.Hash, utxo.N)
+	tb.trackAsset(assetId)
+	sum, err := tb.inSums[assetId].Add(utxo.Value)
+	if err != nil {
+		return tb.fail(err)
+	}
+	tb.inSums[assetId] = sum
+	return tb
+}
+
+func (tb *TransactionBuilder) AddOutput(assetId UInt256, value Fixed8, address string) *TransactionBuilder {
+	scriptHash, err := UInt160FromAddress(address)
+	if err != nil {
+		return tb.fail(err)
+	}
+	if value.Sign() <= 0 {
+		return tb.fail(fmt.Errorf("%w: output value %s", ErrBadAmount, value))
+	}
+	tb.trackAsset(assetId)
+	sum, err := tb.outSums[assetId].Add(value)
+	if err != nil {
+		return tb.fail(err)
+	}
+	tb.outSums[assetId] = sum
+	tb.tx.addOutput(NewTransactionOutput(assetId, value, scriptHash))
+	return tb
+}
+
+func (tb *TransactionBuilder) AddAttribute(attribute Attribute) *TransactionBuilder {
+	tb.tx.addAttribute(attribute)
+	return tb
+}
+
+func (tb *TransactionBuilder) SetExtData(extdata IExtData) *TransactionBuilder {
+	tb.tx.setExtData(extdata)
+	return tb
+}
+
+// SetChangeAddress sets where change of every asset goes unless an asset
+// specific address was given with SetAssetChangeAddress.
+func (tb *TransactionBuilder) SetChangeAddress(address string) *TransactionBuilder {
+	scriptHash, err := UInt160FromAddress(address)
+	if err != nil {
+		return tb.fail(err)
+	}
+	tb.defaultChange = &scriptHash
+	return tb
+}
+
+func (tb *TransactionBuilder) SetAssetChangeAddress(assetId UInt256, address string) *TransactionBuilder {
+	scriptHash, err := UInt160FromAddress(address)
+	if err != nil {
+		return tb.fail(err)
+	}
+	tb.change[assetId] = scriptHash
+	return tb
+}
+
+// NetworkFee sets the network fee to leave unspent from the GAS inputs.
+func (tb *TransactionBuilder) NetworkFee(fee Fixed8) *TransactionBuilder {
+	if fee.Sign() < 0 {
+		return tb.fail(fmt.Errorf("%w: network fee %s", ErrBadAmount, fee))
+	}
+	tb.networkFee = fee
+	return tb
+}
+
+// AutoNetworkFee raises the network fee to the minimum policy asks for
+// the built transaction, counting witnesses of the given sizes that will
+// be added when it is signed. When inputs were picked with SelectInputs,
+// Build picks more GAS from the same utxos if the raised fee needs it.
+func (tb *TransactionBuilder) AutoNetworkFee(policy *FeePolicy, witnessSizes ...int) *TransactionBuilder {
+	tb.feePolicy = policy
+	tb.witnessSizes = witnessSizes
+	return tb
+}
+
+// Build checks the tracked balances, appends one change output per asset
+// that has value left over and returns the unsigned transaction. The
+// system fee and the network fee are kept out of the GAS change.
+func (tb *TransactionBuilder) Build() (*Transaction, error) {
+	if tb.err != nil {
+		return nil, tb.err
+	}
+	if tb.tx.txtype == InvocationTransaction {
+		extdata, ok := tb.tx.extdata.(*InvokeTransData)
+		if !ok {
+			return nil, fmt.Errorf("%w: invocation transaction without script", ErrMissingExtData)
+		}
+		if tb.tx.version < 1 && extdata.gas.Sign() != 0 {
+			return nil, fmt.Errorf("%w: version 0 invocation cannot carry gas %s", ErrBadAmount, extdata.gas)
+		}
+	}
+
+	networkFee := tb.networkFee
+	for {
+		tx, err := tb.assemble(networkFee)
+		if errors.Is(err, ErrInsufficientFunds) && tb.selection != nil && networkFee.GreaterThan(tb.networkFee) {
+			// the inputs were picked for a smaller fee
+			picked, selectErr := tb.selectFeeGas(networkFee)
+			if selectErr != nil && !errors.Is(selectErr, ErrInsufficientFunds) {
+				return nil, selectErr
+			}
+			if picked {
+				continue
+			}
+		}
+		if err != nil || tb.feePolicy == nil {
+			return tx, err
+		}
+		// the fee can change the GAS change and with it the size, so
+		// repeat until the fee covers the transaction it is part of
+		fee, err := tx.MinimumNetworkFee(tb.feePolicy, tb.witnessSizes...)
+		if err != nil {
+			return nil, err
+		}
+		if !fee.GreaterThan(networkFee) {
+			return tx, nil
+		}
+		networkFee = fee
+	}
+}
+
+// selectFeeGas adds GAS inputs from the utxos given to SelectInputs
+// until they cover the GAS outputs and fees under networkFee. It reports
+// whether any input was added.
+func (tb *TransactionBuilder) selectFeeGas(networkFee Fixed8) (bool, error) {
+	need, err := tb.outSums[GasAssetHash].Add(tb.tx.GetSystemFee())
+	if err == nil {
+		need, err = need.Add(networkFee)
+	}
+	if err == nil {
+		need, err = need.Sub(tb.inSums[GasAssetHash])
+	}
+	if err != nil || need.Sign() <= 0 {
+		return false, err
+	}
+
+	spent := make(map[TransactionInput]bool, len(tb.tx.inputs))
+	for _, input := range tb.tx.inputs {
+		spent[input] = true
+	}
+	var unspent []Utxo
+	for _, utxo := range tb.utxos[GasAssetHash] {
+		if !spent[NewTransactionInput(utxo.Hash, utxo.N)] {
+			unspent = append(unspent, utxo)
+		}
+	}
+	limit := 0
+	if tb.selection.MaxInputs > 0 {
+		if limit = tb.selection.MaxInputs - len(tb.tx.inputs); limit <= 0 {
+			return false, fmt.Errorf("%w: no inputs left for the network fee %s", ErrTooManyInputs, networkFee)
+		}
+	}
+	selector := tb.selection.Selector
+	if selector == nil {
+		selector = LargestFirst{}
+	}
+	picked, err := selector.Select(unspent, need, limit)
+	if err != nil {
+		return false, fmt.Errorf("asset %s: %w", GasAssetHash, err)
+	}
+	for _, utxo := range picked {
+		tb.AddUtxo(GasAssetHash, utxo)
+	}
+	return len(picked) > 0, tb.err
+}
+
+func (tb *TransactionBuilder) assemble(networkFee Fixed8) (*Transaction, error) {
+	tx := tb.tx.clone()
+	fees, err := tx.GetSystemFee().Add(networkFee)
+	if err != nil {
+		return nil, err
+	}
+	if _, tracked := tb.inSums[GasAssetHash]; !tracked && fees.Sign() > 0 {
+		return nil, fmt.Errorf("%w of asset %s: fees of %s need GAS inputs", ErrInsufficientFunds, GasAssetHash, fees)
+	}
+	for _, assetId := range tb.assets {
+		in, tracked := tb.inSums[assetId]
+		if !tracked {
+			continue
+		}
+		out := tb.outSums[assetId]
+		if assetId == GasAssetHash {
+			if out, err = out.Add(fees); err != nil {
+				return nil, err
+			}
+		}
+		if in.LessThan(out) {
+			return nil, fmt.Errorf("%w of asset %s: have %s, need %s", ErrInsufficientFunds, assetId, in, out)
+		}
+		if in.Equal(out) {
+			continue
+		}
+		changeAddress, ok := tb.change[assetId]
+		if !ok {
+			if tb.defaultChange == nil {
+				return nil, fmt.Errorf("no change address for asset %s", assetId)
+			}
+			changeAddress = *tb.defaultChange
+		}
+		change, _ := in.Sub(out)
+		tx.addOutput(NewTransactionOutput(assetId, change, changeAddress))
+	}
+
+	if in, tracked := tb.inSums[GasAssetHash]; tracked {
+		fee := in
+		for _, output := range tx.outputs {
+			if output.assetId == GasAssetHash {
+				fee, _ = fee.Sub(output.value)
+			}
+		}
+		tx.networkFee, _ = fee.Sub(tx.GetSystemFee())
+	}
+	return tx, nil
+}
+
+func (self *Transaction) clone() *Transaction {
+	tx := &Transaction{}
+	tx.txtype = self.txtype
+	tx.version = self.version
+	tx.attributes = append([]Attribute(nil), self.attributes...)
+	tx.inputs = append([]TransactionInput(nil), self.inputs...)
+	tx.outputs = append([]TransactionOutput(nil), self.outputs...)
+	tx.witnesses = append([]Witness(nil), self.witnesses...)
+	tx.extdata = self.extdata
+	return tx
+}
+
+// Sign signs the transaction with privKey and adds the matching witness.
+// Call it once per required signer.
+func (self *Transaction) Sign(privKey *ecdsa.PrivateKey) error {
+	unsignedData, err := self.GetMessage()
+	if err != nil {
+		return err
+	}
+	signature, err := Sign(unsignedData, privKey)
+	if err != nil {
+		return err
+	}
+	pubKey := privKey.PublicKey
+	return self.AddWitness(signature, &pubKey, getAddressFromPublicKey(&pubKey))
+}
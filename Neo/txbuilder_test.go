@@ -0,0 +1,95 @@
+package Neo
+
+import (
+	"errors"
+	"testing"
+)
+
+// testUtxos returns n utxos of value, each from its own transaction.
+func testUtxos(n int, value int64) []Utxo {
+	utxos := make([]Utxo, n)
+	for i := range utxos {
+		utxos[i].Hash[0] = byte(i)
+		utxos[i].Hash[1] = byte(i >> 8)
+		utxos[i].Value = Fixed8{value: value}
+	}
+	return utxos
+}
+
+func gasOutputs(tx *Transaction) Fixed8 {
+	sum := Fixed8Zero
+	for _, output := range tx.outputs {
+		if output.assetId == GasAssetHash {
+			sum, _ = sum.Add(output.value)
+		}
+	}
+	return sum
+}
+
+func TestInvocationSystemFee(t *testing.T) {
+	gas := Fixed8{value: 5 * D}
+	for _, test := range []struct {
+		version byte
+		want    Fixed8
+	}{
+		{0, Fixed8Zero},
+		{1, gas},
+	} {
+		tx := NewTransactionBuilder(InvocationTransaction).Version(test.version).tx
+		tx.extdata = NewInvokeTransData([]byte{0x51}, gas)
+		if fee := tx.GetSystemFee(); fee != test.want {
+			t.Errorf("version %d: system fee %s, want %s", test.version, fee, test.want)
+		}
+	}
+
+	_, err := NewTransactionBuilder(InvocationTransaction).SetExtData(NewInvokeTransData([]byte{0x51}, gas)).Build()
+	if !errors.Is(err, ErrBadAmount) {
+		t.Errorf("version 0 invocation with gas: got %v, want ErrBadAmount", err)
+	}
+}
+
+func TestAutoNetworkFeeSelectsMoreGas(t *testing.T) {
+	address := keyVectors[0].address
+	utxos := map[UInt256][]Utxo{GasAssetHash: testUtxos(40, D/10)}
+	policy := DefaultFeePolicy()
+	for _, selector := range []CoinSelector{SmallestFirst{}, LargestFirst{}, BranchAndBound{}} {
+		tx, err := NewTransactionBuilder(ContractTransaction).
+			AddOutput(GasAssetHash, Fixed8{value: 3 * D}, address).
+			SelectInputs(&CoinSelection{Selector: selector}, utxos).
+			SetChangeAddress(address).
+			AutoNetworkFee(policy, SingleSigWitnessSize()).
+			Build()
+		if err != nil {
+			t.Fatalf("%T: %v", selector, err)
+		}
+		fee, err := tx.MinimumNetworkFee(policy, SingleSigWitnessSize())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fee.Sign() == 0 {
+			t.Fatalf("%T: %d inputs need no fee, the test needs more", selector, len(tx.inputs))
+		}
+		in := Fixed8{value: int64(len(tx.inputs)) * D / 10}
+		if paid, _ := in.Sub(gasOutputs(tx)); paid != fee || tx.networkFee != fee {
+			t.Errorf("%T: %d inputs pay %s, network fee %s, want %s", selector, len(tx.inputs), paid, tx.networkFee, fee)
+		}
+	}
+
+	// the fee cannot be covered once the wallet runs out of GAS
+	_, err := NewTransactionBuilder(ContractTransaction).
+		AddOutput(GasAssetHash, Fixed8{value: 4 * D}, address).
+		SelectInputs(&CoinSelection{Selector: SmallestFirst{}}, utxos).
+		SetChangeAddress(address).
+		AutoNetworkFee(policy, SingleSigWitnessSize()).
+		Build()
+	if !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("got %v, want ErrInsufficientFunds", err)
+	}
+}
+
+func TestBuildInvocationWithoutScript(t *testing.T) {
+	_, err := NewTransactionBuilder(InvocationTransaction).Version(1).Build()
+	if !errors.Is(err, ErrMissingExtData) {
+		t.Errorf("got %v, want ErrMissingExtData", err)
+	}
+}
@@ -0,0 +1,552 @@
+package Neo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/neo-thinsdk-go/utils"
+)
+
+// The JSON encoding follows the verbose output of neo-cli, e.g.
+// "getrawtransaction <txid> 1".
+
+var txTypeNames = map[byte]string{
+	MinerTransaction:      "MinerTransaction",
+	IssueTransaction:      "IssueTransaction",
+	ClaimTransaction:      "ClaimTransaction",
+	EnrollmentTransaction: "EnrollmentTransaction",
+	RegisterTransaction:   "RegisterTransaction",
+	ContractTransaction:   "ContractTransaction",
+	StateTransaction:      "StateTransaction",
+	PublishTransaction:    "PublishTransaction",
+	InvocationTransaction: "InvocationTransaction",
+}
+
+var usageNames = map[byte]string{
+	ContractHash:   "ContractHash",
+	ECDH02:         "ECDH02",
+	ECDH03:         "ECDH03",
+	Script:         "Script",
+	Vote:           "Vote",
+	DescriptionUrl: "DescriptionUrl",
+	Description:    "Description",
+}
+
+var assetTypeNames = map[byte]string{
+	AssetCreditFlag:     "CreditFlag",
+	AssetDutyFlag:       "DutyFlag",
+	AssetGoverningToken: "GoverningToken",
+	AssetUtilityToken:   "UtilityToken",
+	AssetCurrency:       "Currency",
+	AssetShare:          "Share",
+	AssetInvoice:        "Invoice",
+	AssetToken:          "Token",
+}
+
+var stateTypeNames = map[byte]string{
+	StateTypeAccount:   "Account",
+	StateTypeValidator: "Validator",
+}
+
+func init() {
+	for i := byte(0); i < 15; i++ {
+		usageNames[Hash1+i] = fmt.Sprintf("Hash%d", i+1)
+		usageNames[Remark1+i] = fmt.Sprintf("Remark%d", i+1)
+	}
+	usageNames[Remark] = "Remark"
+}
+
+func nameOf(names map[byte]string, value byte) string {
+	if name, ok := names[value]; ok {
+		return name
+	}
+	return fmt.Sprintf("%d", value)
+}
+
+func valueOf(names map[byte]string, name string) (byte, bool) {
+	for value, n := range names {
+		if n == name {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+func parseHex(str string) ([]byte, error) {
+	data, ok := utils.ToBytes(strings.TrimPrefix(str, "0x"))
+	if !ok {
+		return nil, fmt.Errorf("invalid hex string %q", str)
+	}
+	return data, nil
+}
+
+type attributeJSON struct {
+	Usage string `json:"usage"`
+	Data  string `json:"data"`
+}
+
+func (self Attribute) MarshalJSON() ([]byte, error) {
+	return json.Marshal(attributeJSON{
+		Usage: nameOf(usageNames, self.usage),
+		Data:  utils.ToHexString(self.data),
+	})
+}
+
+func (self *Attribute) UnmarshalJSON(data []byte) error {
+	var aux attributeJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	usage, ok := valueOf(usageNames, aux.Usage)
+	if !ok {
+		return fmt.Errorf("%w: unknown usage %q", ErrBadAttribute, aux.Usage)
+	}
+	attriData, err := parseHex(aux.Data)
+	if err != nil {
+		return err
+	}
+	self.usage = usage
+	self.data = attriData
+	return nil
+}
+
+type inputJSON struct {
+	Txid UInt256 `json:"txid"`
+	Vout uint16  `json:"vout"`
+}
+
+func (self TransactionInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(inputJSON{Txid: self.hash, Vout: self.index})
+}
+
+func (self *TransactionInput) UnmarshalJSON(data []byte) error {
+	var aux inputJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	self.hash = aux.Txid
+	self.index = aux.Vout
+	return nil
+}
+
+// outputJSON carries "n" only inside a transaction, where the index of
+// the output is known.
+type outputJSON struct {
+	N       *int    `json:"n,omitempty"`
+	Asset   UInt256 `json:"asset"`
+	Value   string  `json:"value"`
+	Address string  `json:"address"`
+}
+
+func (self *TransactionOutput) toJSON() outputJSON {
+	return outputJSON{
+		Asset:   self.assetId,
+		Value:   self.value.String(),
+		Address: self.scriptHash.Address(),
+	}
+}
+
+func (self TransactionOutput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(self.toJSON())
+}
+
+func (self *TransactionOutput) UnmarshalJSON(data []byte) error {
+	var aux outputJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	value, err := ParseFixed8(aux.Value)
+	if err != nil {
+		return err
+	}
+	scriptHash, err := UInt160FromAddress(aux.Address)
+	if err != nil {
+		return err
+	}
+	self.assetId = aux.Asset
+	self.value = value
+	self.scriptHash = scriptHash
+	return nil
+}
+
+type witnessJSON struct {
+	Invocation   string `json:"invocation"`
+	Verification string `json:"verification"`
+}
+
+func (self Witness) MarshalJSON() ([]byte, error) {
+	return json.Marshal(witnessJSON{
+		Invocation:   utils.ToHexString(self.InvocationScript),
+		Verification: utils.ToHexString(self.VerificationScript),
+	})
+}
+
+func (self *Witness) UnmarshalJSON(data []byte) error {
+	var aux witnessJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	invocation, err := parseHex(aux.Invocation)
+	if err != nil {
+		return err
+	}
+	verification, err := parseHex(aux.Verification)
+	if err != nil {
+		return err
+	}
+	self.InvocationScript = invocation
+	self.VerificationScript = verification
+	return nil
+}
+
+// extDataJSON is implemented by the extdata types of this package to add
+// their fields to the transaction object and read them back.
+type extDataJSON interface {
+	marshalJSONFields(tx *Transaction, fields map[string]interface{})
+	unmarshalJSONFields(tx *Transaction, fields map[string]json.RawMessage) error
+}
+
+// MarshalJSON encodes the transaction like neo-cli. The network fee is
+// only known for transactions built with TransactionBuilder from tracked
+// UTXOs or decoded from JSON, since the encoding does not carry the
+// values of the inputs; otherwise it is reported as 0.
+func (self *Transaction) MarshalJSON() ([]byte, error) {
+	raw, err := self.GetRawData()
+	if err != nil {
+		return nil, err
+	}
+	txid, err := self.GetTxid()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{})
+	fields["txid"] = txid
+	fields["size"] = len(raw)
+	fields["type"] = nameOf(txTypeNames, self.txtype)
+	fields["version"] = self.version
+	fields["attributes"] = nonNil(self.attributes)
+	fields["vin"] = nonNil(self.inputs)
+	outputs := make([]outputJSON, len(self.outputs))
+	for i := range self.outputs {
+		n := i
+		outputs[i] = self.outputs[i].toJSON()
+		outputs[i].N = &n
+	}
+	fields["vout"] = outputs
+	fields["sys_fee"] = self.GetSystemFee().String()
+	fields["net_fee"] = self.networkFee.String()
+	fields["scripts"] = nonNil(self.witnesses)
+	if ext, ok := self.extdata.(extDataJSON); ok {
+		ext.marshalJSONFields(self, fields)
+	}
+	return json.Marshal(fields)
+}
+
+func nonNil(list interface{}) interface{} {
+	switch v := list.(type) {
+	case []Attribute:
+		if v == nil {
+			return []Attribute{}
+		}
+	case []TransactionInput:
+		if v == nil {
+			return []TransactionInput{}
+		}
+	case []Witness:
+		if v == nil {
+			return []Witness{}
+		}
+	}
+	return list
+}
+
+func (self *Transaction) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var aux struct {
+		Type       string              `json:"type"`
+		Version    byte                `json:"version"`
+		Attributes []Attribute         `json:"attributes"`
+		Vin        []TransactionInput  `json:"vin"`
+		Vout       []TransactionOutput `json:"vout"`
+		NetFee     string              `json:"net_fee"`
+		Scripts    []Witness           `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	txtype, ok := valueOf(txTypeNames, aux.Type)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownTxType, aux.Type)
+	}
+
+	tx := Transaction{}
+	tx.txtype = txtype
+	tx.version = aux.Version
+	tx.attributes = aux.Attributes
+	tx.inputs = aux.Vin
+	tx.outputs = aux.Vout
+	tx.witnesses = aux.Scripts
+	if aux.NetFee != "" {
+		netFee, err := ParseFixed8(aux.NetFee)
+		if err != nil {
+			return err
+		}
+		tx.networkFee = netFee
+	}
+	extdata, _ := newExtData(txtype)
+	if ext, ok := extdata.(extDataJSON); ok {
+		if err := ext.unmarshalJSONFields(&tx, fields); err != nil {
+			return err
+		}
+	}
+	tx.extdata = extdata
+	*self = tx
+	return nil
+}
+
+// decodeFields unmarshals the named raw fields into the given targets,
+// skipping fields that are absent.
+func decodeFields(fields map[string]json.RawMessage, targets map[string]interface{}) error {
+	for name, target := range targets {
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, target); err != nil {
+			return fmt.Errorf("field %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+func (self *InvokeTransData) marshalJSONFields(tx *Transaction, fields map[string]interface{}) {
+	fields["script"] = utils.ToHexString(self.script)
+	fields["gas"] = self.gas.String()
+}
+
+func (self *InvokeTransData) unmarshalJSONFields(tx *Transaction, fields map[string]json.RawMessage) error {
+	var script, gas string
+	if err := decodeFields(fields, map[string]interface{}{"script": &script, "gas": &gas}); err != nil {
+		return err
+	}
+	data, err := parseHex(script)
+	if err != nil {
+		return err
+	}
+	self.script = data
+	if gas != "" {
+		if self.gas, err = ParseFixed8(gas); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (self *MinerTransData) marshalJSONFields(tx *Transaction, fields map[string]interface{}) {
+	fields["nonce"] = self.nonce
+}
+
+func (self *MinerTransData) unmarshalJSONFields(tx *Transaction, fields map[string]json.RawMessage) error {
+	return decodeFields(fields, map[string]interface{}{"nonce": &self.nonce})
+}
+
+func (self *ClaimTransData) marshalJSONFields(tx *Transaction, fields map[string]interface{}) {
+	fields["claims"] = nonNil(self.claims)
+}
+
+func (self *ClaimTransData) unmarshalJSONFields(tx *Transaction, fields map[string]json.RawMessage) error {
+	return decodeFields(fields, map[string]interface{}{"claims": &self.claims})
+}
+
+func (self *EnrollmentTransData) marshalJSONFields(tx *Transaction, fields map[string]interface{}) {
+	fields["pubkey"] = utils.ToHexString(self.publicKey)
+}
+
+func (self *EnrollmentTransData) unmarshalJSONFields(tx *Transaction, fields map[string]json.RawMessage) error {
+	var pubkey string
+	if err := decodeFields(fields, map[string]interface{}{"pubkey": &pubkey}); err != nil {
+		return err
+	}
+	publicKey, err := parseHex(pubkey)
+	self.publicKey = publicKey
+	return err
+}
+
+type assetJSON struct {
+	Type      string          `json:"type"`
+	Name      json.RawMessage `json:"name"`
+	Amount    string          `json:"amount"`
+	Precision byte            `json:"precision"`
+	Owner     string          `json:"owner"`
+	Admin     string          `json:"admin"`
+}
+
+func (self *RegisterTransData) marshalJSONFields(tx *Transaction, fields map[string]interface{}) {
+	asset := assetJSON{}
+	asset.Type = nameOf(assetTypeNames, self.assetType)
+	// neo-cli embeds the name as JSON when it parses, else as a string
+	if self.name == "" {
+		asset.Name = json.RawMessage("null")
+	} else if json.Valid([]byte(self.name)) {
+		asset.Name = json.RawMessage(self.name)
+	} else {
+		asset.Name, _ = json.Marshal(self.name)
+	}
+	asset.Amount = self.amount.String()
+	asset.Precision = self.precision
+	asset.Owner = utils.ToHexString(self.owner)
+	asset.Admin = self.admin.Address()
+	fields["asset"] = asset
+}
+
+func (self *RegisterTransData) unmarshalJSONFields(tx *Transaction, fields map[string]json.RawMessage) error {
+	var asset assetJSON
+	if err := decodeFields(fields, map[string]interface{}{"asset": &asset}); err != nil {
+		return err
+	}
+	assetType, ok := valueOf(assetTypeNames, asset.Type)
+	if !ok {
+		return fmt.Errorf("unknown asset type %q", asset.Type)
+	}
+	self.assetType = assetType
+
+	var name string
+	if err := json.Unmarshal(asset.Name, &name); err == nil {
+		self.name = name
+	} else if string(asset.Name) != "null" {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, asset.Name); err != nil {
+			return err
+		}
+		self.name = compact.String()
+	}
+
+	amount, err := ParseFixed8(asset.Amount)
+	if err != nil {
+		return err
+	}
+	self.amount = amount
+	self.precision = asset.Precision
+	if self.owner, err = parseHex(asset.Owner); err != nil {
+		return err
+	}
+	if self.admin, err = UInt160FromAddress(asset.Admin); err != nil {
+		return err
+	}
+	return nil
+}
+
+type stateDescriptorJSON struct {
+	Type  string `json:"type"`
+	Key   string `json:"key"`
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+func (self *StateTransData) marshalJSONFields(tx *Transaction, fields map[string]interface{}) {
+	descriptors := make([]stateDescriptorJSON, len(self.descriptors))
+	for i, desc := range self.descriptors {
+		descriptors[i] = stateDescriptorJSON{
+			Type:  nameOf(stateTypeNames, desc.descType),
+			Key:   utils.ToHexString(desc.key),
+			Field: desc.field,
+			Value: utils.ToHexString(desc.value),
+		}
+	}
+	fields["descriptors"] = descriptors
+}
+
+func (self *StateTransData) unmarshalJSONFields(tx *Transaction, fields map[string]json.RawMessage) error {
+	var descriptors []stateDescriptorJSON
+	if err := decodeFields(fields, map[string]interface{}{"descriptors": &descriptors}); err != nil {
+		return err
+	}
+	self.descriptors = make([]StateDescriptor, len(descriptors))
+	for i, aux := range descriptors {
+		descType, ok := valueOf(stateTypeNames, aux.Type)
+		if !ok {
+			return fmt.Errorf("unknown state type %q", aux.Type)
+		}
+		key, err := parseHex(aux.Key)
+		if err != nil {
+			return err
+		}
+		value, err := parseHex(aux.Value)
+		if err != nil {
+			return err
+		}
+		self.descriptors[i] = StateDescriptor{descType: descType, key: key, field: aux.Field, value: value}
+	}
+	return nil
+}
+
+type contractJSON struct {
+	Code struct {
+		Hash       string   `json:"hash"`
+		Script     string   `json:"script"`
+		Parameters []string `json:"parameters"`
+		ReturnType string   `json:"returntype"`
+	} `json:"code"`
+	NeedStorage bool   `json:"needstorage"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Author      string `json:"author"`
+	Email       string `json:"email"`
+	Description string `json:"description"`
+}
+
+func (self *PublishTransData) marshalJSONFields(tx *Transaction, fields map[string]interface{}) {
+	contract := contractJSON{}
+	contract.Code.Hash = UInt160FromScript(self.script).String()
+	contract.Code.Script = utils.ToHexString(self.script)
+	contract.Code.Parameters = make([]string, len(self.parameterList))
+	for i, p := range self.parameterList {
+		contract.Code.Parameters[i] = nameOf(parameterTypeNames, p)
+	}
+	contract.Code.ReturnType = nameOf(parameterTypeNames, self.returnType)
+	contract.NeedStorage = self.needStorage
+	contract.Name = self.name
+	contract.Version = self.codeVersion
+	contract.Author = self.author
+	contract.Email = self.email
+	contract.Description = self.description
+	fields["contract"] = contract
+}
+
+func (self *PublishTransData) unmarshalJSONFields(tx *Transaction, fields map[string]json.RawMessage) error {
+	var contract contractJSON
+	if err := decodeFields(fields, map[string]interface{}{"contract": &contract}); err != nil {
+		return err
+	}
+	script, err := parseHex(contract.Code.Script)
+	if err != nil {
+		return err
+	}
+	self.script = script
+	self.parameterList = make([]byte, len(contract.Code.Parameters))
+	for i, name := range contract.Code.Parameters {
+		p, ok := valueOf(parameterTypeNames, name)
+		if !ok {
+			return fmt.Errorf("unknown parameter type %q", name)
+		}
+		self.parameterList[i] = p
+	}
+	returnType, ok := valueOf(parameterTypeNames, contract.Code.ReturnType)
+	if !ok {
+		return fmt.Errorf("unknown return type %q", contract.Code.ReturnType)
+	}
+	self.returnType = returnType
+	self.needStorage = contract.NeedStorage
+	self.name = contract.Name
+	self.codeVersion = contract.Version
+	self.author = contract.Author
+	self.email = contract.Email
+	self.description = contract.Description
+	return nil
+}
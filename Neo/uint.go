@@ -0,0 +1,191 @@
+package Neo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/neo-thinsdk-go/utils"
+)
+
+// UInt160 is a 20 byte hash such as a script hash. It is kept in the
+// little-endian order used on the wire; String and ParseUInt160 use the
+// big-endian "0x..." form shown by neo-cli.
+type UInt160 [20]byte
+
+// UInt256 is a 32 byte hash such as a txid or an asset id, stored
+// little-endian like UInt160.
+type UInt256 [32]byte
+
+func parseBigEndianHex(str string, size int) ([]byte, bool) {
+	data, ok := utils.ToBytes(strings.TrimPrefix(str, "0x"))
+	if !ok || len(data) != size {
+		return nil, false
+	}
+	return utils.BytesReverse(data), true
+}
+
+// UInt160FromBytes takes little-endian bytes.
+func UInt160FromBytes(data []byte) (UInt160, error) {
+	var u UInt160
+	if len(data) != len(u) {
+		return u, fmt.Errorf("%w: length %d", ErrBadScriptHash, len(data))
+	}
+	copy(u[:], data)
+	return u, nil
+}
+
+// ParseUInt160 parses big-endian hex, with or without the 0x prefix.
+func ParseUInt160(str string) (UInt160, error) {
+	data, ok := parseBigEndianHex(str, 20)
+	if !ok {
+		return UInt160{}, fmt.Errorf("%w: %q", ErrBadScriptHash, str)
+	}
+	return UInt160FromBytes(data)
+}
+
+// UInt160FromAddress decodes the script hash of a Neo address.
+func UInt160FromAddress(address string) (UInt160, error) {
+	data, ok := getPublicKeyHashFromAddress(address)
+	if !ok {
+		return UInt160{}, fmt.Errorf("%w: %q", ErrBadAddress, address)
+	}
+	return UInt160FromBytes(data)
+}
+
+// UInt160FromScript returns the hash of a verification or contract script.
+func UInt160FromScript(script []byte) UInt160 {
+	u, _ := UInt160FromBytes(getScriptHashFromScript(script))
+	return u
+}
+
+// Bytes returns a little-endian copy.
+func (u UInt160) Bytes() []byte {
+	data := make([]byte, len(u))
+	copy(data, u[:])
+	return data
+}
+
+// BytesBE returns the big-endian bytes.
+func (u UInt160) BytesBE() []byte {
+	return utils.BytesReverse(u[:])
+}
+
+func (u UInt160) String() string {
+	return "0x" + utils.ToHexString(u.BytesBE())
+}
+
+func (u UInt160) Address() string {
+	address, _ := getAddressFromScriptHash(u[:])
+	return address
+}
+
+func (u UInt160) Equals(other UInt160) bool {
+	return u == other
+}
+
+// CompareTo orders hashes by numeric value, as the reference
+// implementation does, so the last little-endian byte is the most
+// significant.
+func (u UInt160) CompareTo(other UInt160) int {
+	return compareLittleEndian(u[:], other[:])
+}
+
+func (u UInt160) Less(other UInt160) bool {
+	return u.CompareTo(other) < 0
+}
+
+// MarshalText implements encoding.TextMarshaler, which encoding/json also
+// uses, with the "0x..." form.
+func (u UInt160) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+func (u *UInt160) UnmarshalText(text []byte) error {
+	value, err := ParseUInt160(string(text))
+	if err != nil {
+		return err
+	}
+	*u = value
+	return nil
+}
+
+// UInt256FromBytes takes little-endian bytes.
+func UInt256FromBytes(data []byte) (UInt256, error) {
+	var u UInt256
+	if len(data) != len(u) {
+		return u, fmt.Errorf("%w: length %d", ErrBadHash, len(data))
+	}
+	copy(u[:], data)
+	return u, nil
+}
+
+// ParseUInt256 parses big-endian hex, with or without the 0x prefix.
+func ParseUInt256(str string) (UInt256, error) {
+	data, ok := parseBigEndianHex(str, 32)
+	if !ok {
+		return UInt256{}, fmt.Errorf("%w: %q", ErrBadHash, str)
+	}
+	return UInt256FromBytes(data)
+}
+
+// Bytes returns a little-endian copy.
+func (u UInt256) Bytes() []byte {
+	data := make([]byte, len(u))
+	copy(data, u[:])
+	return data
+}
+
+// BytesBE returns the big-endian bytes.
+func (u UInt256) BytesBE() []byte {
+	return utils.BytesReverse(u[:])
+}
+
+func (u UInt256) String() string {
+	return "0x" + utils.ToHexString(u.BytesBE())
+}
+
+func (u UInt256) Equals(other UInt256) bool {
+	return u == other
+}
+
+// CompareTo orders hashes by numeric value like UInt160.CompareTo.
+func (u UInt256) CompareTo(other UInt256) int {
+	return compareLittleEndian(u[:], other[:])
+}
+
+func (u UInt256) Less(other UInt256) bool {
+	return u.CompareTo(other) < 0
+}
+
+// MarshalText implements encoding.TextMarshaler, which encoding/json also
+// uses, with the "0x..." form.
+func (u UInt256) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+func (u *UInt256) UnmarshalText(text []byte) error {
+	value, err := ParseUInt256(string(text))
+	if err != nil {
+		return err
+	}
+	*u = value
+	return nil
+}
+
+func compareLittleEndian(a, b []byte) int {
+	for i := len(a) - 1; i >= 0; i-- {
+		if a[i] < b[i] {
+			return -1
+		}
+		if a[i] > b[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// NeoAssetHash and GasAssetHash are NeoAssetId and GasAssetId as UInt256.
+var (
+	NeoAssetHash, _ = ParseUInt256(NeoAssetId)
+	GasAssetHash, _ = ParseUInt256(GasAssetId)
+)
@@ -0,0 +1,172 @@
+package Neo
+
+import (
+	"fmt"
+)
+
+// ValidationPolicy configures Transaction.Validate. The zero value of a
+// limit turns its check off.
+type ValidationPolicy struct {
+	MaxTransactionSize int
+	MaxAttributes      int
+	// AssetPrecision gives the decimals of assets other than NEO and GAS.
+	// Outputs of assets missing here are not checked for precision.
+	AssetPrecision map[UInt256]byte
+	// Lookup resolves spent outputs so the witnesses can be matched with
+	// the required script hashes. Without it that check is skipped.
+	Lookup ReferenceLookup
+}
+
+// DefaultValidationPolicy returns the limits of a Neo 2 node.
+func DefaultValidationPolicy() *ValidationPolicy {
+	return &ValidationPolicy{
+		MaxTransactionSize: 102400,
+		MaxAttributes:      16,
+	}
+}
+
+type ViolationKind int
+
+const (
+	ViolationSerialize ViolationKind = iota
+	ViolationDuplicateInput
+	ViolationOutputValue
+	ViolationOutputOverflow
+	ViolationPrecision
+	ViolationAttributeCount
+	ViolationAttribute
+	ViolationSize
+	ViolationWitnesses
+	ViolationInvocationGas
+)
+
+var violationNames = map[ViolationKind]string{
+	ViolationSerialize:      "serialize",
+	ViolationDuplicateInput: "duplicate input",
+	ViolationOutputValue:    "output value",
+	ViolationOutputOverflow: "output overflow",
+	ViolationPrecision:      "precision",
+	ViolationAttributeCount: "attribute count",
+	ViolationAttribute:      "attribute",
+	ViolationSize:           "size",
+	ViolationWitnesses:      "witnesses",
+	ViolationInvocationGas:  "invocation gas",
+}
+
+func (k ViolationKind) String() string {
+	if name, ok := violationNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("ViolationKind(%d)", int(k))
+}
+
+// Violation is one broken rule. Index is the position of the offending
+// input, output, attribute or witness, or -1 when the rule concerns the
+// whole transaction.
+type Violation struct {
+	Kind    ViolationKind
+	Index   int
+	Message string
+}
+
+func (v Violation) Error() string {
+	if v.Index < 0 {
+		return fmt.Sprintf("%s: %s", v.Kind, v.Message)
+	}
+	return fmt.Sprintf("%s [%d]: %s", v.Kind, v.Index, v.Message)
+}
+
+// assetPrecision returns the decimals of assetId under policy.
+func (policy *ValidationPolicy) assetPrecision(assetId UInt256) (byte, bool) {
+	switch assetId {
+	case NeoAssetHash:
+		return 0, true
+	case GasAssetHash:
+		return 8, true
+	}
+	precision, ok := policy.AssetPrecision[assetId]
+	return precision, ok
+}
+
+// Validate checks the transaction against the rules a node applies before
+// accepting it and returns every violation found, or nil. A nil policy
+// means DefaultValidationPolicy.
+func (self *Transaction) Validate(policy *ValidationPolicy) []Violation {
+	if policy == nil {
+		policy = DefaultValidationPolicy()
+	}
+	var violations []Violation
+	add := func(kind ViolationKind, index int, format string, args ...interface{}) {
+		violations = append(violations, Violation{Kind: kind, Index: index, Message: fmt.Sprintf(format, args...)})
+	}
+
+	spent := make(map[TransactionInput]int)
+	for i, input := range self.inputs {
+		if first, ok := spent[input]; ok {
+			add(ViolationDuplicateInput, i, "%s:%d is also spent by input %d", input.hash, input.index, first)
+			continue
+		}
+		spent[input] = i
+	}
+
+	sums := make(map[UInt256]Fixed8)
+	for i, output := range self.outputs {
+		if output.value.Sign() <= 0 {
+			add(ViolationOutputValue, i, "value %s is not positive", output.value)
+		}
+		sum, err := sums[output.assetId].Add(output.value)
+		if err != nil {
+			add(ViolationOutputOverflow, i, "total of asset %s overflows", output.assetId)
+		}
+		sums[output.assetId] = sum
+		if precision, ok := policy.assetPrecision(output.assetId); ok && precision < 8 {
+			unit := int64(1)
+			for p := precision; p < 8; p++ {
+				unit *= 10
+			}
+			if output.value.value%unit != 0 {
+				add(ViolationPrecision, i, "value %s exceeds %d decimals of asset %s", output.value, precision, output.assetId)
+			}
+		}
+	}
+
+	if policy.MaxAttributes > 0 && len(self.attributes) > policy.MaxAttributes {
+		add(ViolationAttributeCount, -1, "%d attributes, at most %d allowed", len(self.attributes), policy.MaxAttributes)
+	}
+	for i, attribute := range self.attributes {
+		if err := checkAttribute(attribute.usage, attribute.data); err != nil {
+			add(ViolationAttribute, i, "%v", err)
+		}
+	}
+
+	if size, err := self.Size(); err != nil {
+		add(ViolationSerialize, -1, "%v", err)
+	} else if policy.MaxTransactionSize > 0 && size > policy.MaxTransactionSize {
+		add(ViolationSize, -1, "%d bytes, at most %d allowed", size, policy.MaxTransactionSize)
+	}
+
+	if policy.Lookup != nil {
+		hashes, err := self.GetScriptHashesForVerifying(policy.Lookup)
+		if err != nil {
+			add(ViolationWitnesses, -1, "%v", err)
+		} else if len(hashes) != len(self.witnesses) {
+			add(ViolationWitnesses, -1, "%d witnesses for %d required script hashes", len(self.witnesses), len(hashes))
+		} else {
+			for i, witness := range self.witnesses {
+				if len(witness.VerificationScript) == 0 {
+					continue
+				}
+				if hash := UInt160FromScript(witness.VerificationScript); hash != hashes[i] {
+					add(ViolationWitnesses, i, "witness of %s where %s is required", hash.Address(), hashes[i].Address())
+				}
+			}
+		}
+	}
+
+	if extdata, ok := self.extdata.(*InvokeTransData); ok {
+		if extdata.gas.Sign() < 0 || extdata.gas.value%D != 0 {
+			add(ViolationInvocationGas, -1, "gas %s is not a whole non-negative amount", extdata.gas)
+		}
+	}
+	return violations
+}
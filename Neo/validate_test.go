@@ -0,0 +1,95 @@
+package Neo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	refs := signedVectorReferences(t)
+	if violations := decodeVector(t, signedVector).Validate(&ValidationPolicy{Lookup: refs}); violations != nil {
+		t.Fatalf("signed vector: %v", violations)
+	}
+
+	token := UInt256{0x01}
+	tests := []struct {
+		name   string
+		change func(tx *Transaction, policy *ValidationPolicy)
+		kind   ViolationKind
+		index  int
+	}{
+		{"duplicate input", func(tx *Transaction, policy *ValidationPolicy) {
+			tx.inputs = append(tx.inputs, tx.inputs[0])
+		}, ViolationDuplicateInput, 2},
+		{"zero output", func(tx *Transaction, policy *ValidationPolicy) {
+			tx.outputs[0].value = Fixed8Zero
+		}, ViolationOutputValue, 0},
+		{"negative output", func(tx *Transaction, policy *ValidationPolicy) {
+			tx.outputs[0].value = Fixed8{value: -D}
+		}, ViolationOutputValue, 0},
+		{"outputs overflow", func(tx *Transaction, policy *ValidationPolicy) {
+			tx.outputs[0].value = Fixed8{value: math.MaxInt64 - math.MaxInt64%D}
+			tx.outputs = append(tx.outputs, tx.outputs[0])
+		}, ViolationOutputOverflow, 1},
+		{"fraction of NEO", func(tx *Transaction, policy *ValidationPolicy) {
+			tx.outputs[0].value = Fixed8{value: D / 2}
+		}, ViolationPrecision, 0},
+		{"beyond asset precision", func(tx *Transaction, policy *ValidationPolicy) {
+			tx.outputs[0].assetId = token
+			tx.outputs[0].value = Fixed8{value: D / 1000}
+			policy.AssetPrecision = map[UInt256]byte{token: 2}
+		}, ViolationPrecision, 0},
+		{"too many attributes", func(tx *Transaction, policy *ValidationPolicy) {
+			for i := 0; i < 17; i++ {
+				attribute, _ := NewRemarkAttribute("remark")
+				tx.attributes = append(tx.attributes, attribute)
+			}
+		}, ViolationAttributeCount, -1},
+		{"oversized attribute", func(tx *Transaction, policy *ValidationPolicy) {
+			tx.attributes = []Attribute{{usage: DescriptionUrl, data: make([]byte, 256)}}
+		}, ViolationAttribute, 0},
+		{"bad attribute length", func(tx *Transaction, policy *ValidationPolicy) {
+			tx.attributes = []Attribute{{usage: Script, data: make([]byte, 19)}}
+		}, ViolationAttribute, 0},
+		{"oversized transaction", func(tx *Transaction, policy *ValidationPolicy) {
+			policy.MaxTransactionSize = 100
+		}, ViolationSize, -1},
+		{"witness missing", func(tx *Transaction, policy *ValidationPolicy) {
+			tx.witnesses = tx.witnesses[:1]
+		}, ViolationWitnesses, -1},
+		{"surplus witness", func(tx *Transaction, policy *ValidationPolicy) {
+			tx.witnesses = append(tx.witnesses, tx.witnesses[0])
+		}, ViolationWitnesses, -1},
+		{"witnesses swapped", func(tx *Transaction, policy *ValidationPolicy) {
+			tx.witnesses[0], tx.witnesses[1] = tx.witnesses[1], tx.witnesses[0]
+		}, ViolationWitnesses, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx := decodeVector(t, signedVector)
+			policy := DefaultValidationPolicy()
+			policy.Lookup = refs
+			test.change(tx, policy)
+			violations := tx.Validate(policy)
+			for _, violation := range violations {
+				if violation.Kind == test.kind && violation.Index == test.index {
+					return
+				}
+			}
+			t.Errorf("violations %v, want %s at %d", violations, test.kind, test.index)
+		})
+	}
+}
+
+func TestValidateInvocationGas(t *testing.T) {
+	for gas, ok := range map[int64]bool{0: true, D: true, D / 2: false, -D: false} {
+		tx := &Transaction{txtype: InvocationTransaction, version: 1, extdata: NewInvokeTransData([]byte{0x51}, Fixed8{value: gas})}
+		violations := tx.Validate(nil)
+		if ok && violations != nil {
+			t.Errorf("gas %s: %v", Fixed8{value: gas}, violations)
+		}
+		if !ok && (len(violations) != 1 || violations[0].Kind != ViolationInvocationGas) {
+			t.Errorf("gas %s: violations %v, want invocation gas", Fixed8{value: gas}, violations)
+		}
+	}
+}
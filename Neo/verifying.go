@@ -0,0 +1,206 @@
+package Neo
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ReferenceLookup finds the outputs spent by transaction inputs and
+// claims, usually from a node or an indexer.
+type ReferenceLookup interface {
+	GetReference(input TransactionInput) (TransactionOutput, bool)
+}
+
+// AssetLookup can be implemented next to ReferenceLookup to describe
+// assets other than NEO and GAS. It is needed for IssueTransaction and for
+// outputs of assets that may carry the duty flag.
+type AssetLookup interface {
+	GetAsset(assetId UInt256) (AssetState, bool)
+}
+
+// AssetState holds the parts of a registered asset that decide who must
+// sign a transaction.
+type AssetState struct {
+	AssetType byte
+	Issuer    UInt160
+}
+
+// References is a ReferenceLookup backed by a map, for callers that
+// already know the spent outputs.
+type References map[TransactionInput]TransactionOutput
+
+func (refs References) GetReference(input TransactionInput) (TransactionOutput, bool) {
+	output, ok := refs[input]
+	return output, ok
+}
+
+func lookupAsset(lookup ReferenceLookup, assetId UInt256) (AssetState, error) {
+	switch assetId {
+	case NeoAssetHash:
+		return AssetState{AssetType: AssetGoverningToken}, nil
+	case GasAssetHash:
+		return AssetState{AssetType: AssetUtilityToken}, nil
+	}
+	if assets, ok := lookup.(AssetLookup); ok {
+		if asset, ok := assets.GetAsset(assetId); ok {
+			return asset, nil
+		}
+	}
+	return AssetState{}, fmt.Errorf("%w: %s", ErrUnknownAsset, assetId)
+}
+
+// compressPublicKey returns an encoded public key in the compressed form
+// Neo uses in scripts and states.
+func compressPublicKey(publicKey []byte) ([]byte, error) {
+	switch {
+	case len(publicKey) == 1 && publicKey[0] == 0x00:
+		return publicKey, nil
+	case len(publicKey) == 33 && (publicKey[0] == 0x02 || publicKey[0] == 0x03):
+		return publicKey, nil
+	case len(publicKey) == 65 && (publicKey[0] == 0x04 || publicKey[0] == 0x06 || publicKey[0] == 0x07):
+		key := make([]byte, 33)
+		key[0] = 0x02 | publicKey[64]&1
+		copy(key[1:], publicKey[1:33])
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: %d bytes", ErrBadPublicKey, len(publicKey))
+}
+
+// signatureScriptHash returns the hash of the single signature
+// verification script of an encoded public key.
+func signatureScriptHash(publicKey []byte) (UInt160, error) {
+	key, err := compressPublicKey(publicKey)
+	if err != nil {
+		return UInt160{}, err
+	}
+	script := append([]byte{byte(len(key))}, key...)
+	script = append(script, 0xac)
+	return UInt160FromScript(script), nil
+}
+
+// GetScriptHashesForVerifying returns, in ascending order, the script
+// hashes that must have a witness on the transaction, following the rules
+// of the reference implementation for each transaction type.
+func (self *Transaction) GetScriptHashesForVerifying(lookup ReferenceLookup) ([]UInt160, error) {
+	hashes := make(map[UInt160]bool)
+	for _, input := range self.inputs {
+		output, ok := lookup.GetReference(input)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s:%d", ErrNoReference, input.hash, input.index)
+		}
+		hashes[output.scriptHash] = true
+	}
+	for _, attribute := range self.attributes {
+		if hash, ok := attribute.ScriptHash(); ok {
+			hashes[hash] = true
+		}
+	}
+	checked := make(map[UInt256]bool)
+	for _, output := range self.outputs {
+		if checked[output.assetId] {
+			continue
+		}
+		checked[output.assetId] = true
+		asset, err := lookupAsset(lookup, output.assetId)
+		if err != nil {
+			return nil, err
+		}
+		if asset.AssetType&AssetDutyFlag != 0 {
+			for _, other := range self.outputs {
+				if other.assetId == output.assetId {
+					hashes[other.scriptHash] = true
+				}
+			}
+		}
+	}
+
+	switch extdata := self.extdata.(type) {
+	case *ClaimTransData:
+		for _, claim := range extdata.claims {
+			output, ok := lookup.GetReference(claim)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s:%d", ErrNoReference, claim.hash, claim.index)
+			}
+			hashes[output.scriptHash] = true
+		}
+	case *EnrollmentTransData:
+		hash, err := signatureScriptHash(extdata.publicKey)
+		if err != nil {
+			return nil, err
+		}
+		hashes[hash] = true
+	case *RegisterTransData:
+		hash, err := signatureScriptHash(extdata.owner)
+		if err != nil {
+			return nil, err
+		}
+		hashes[hash] = true
+	case *StateTransData:
+		for _, desc := range extdata.descriptors {
+			switch desc.descType {
+			case StateTypeAccount:
+				hash, err := UInt160FromBytes(desc.key)
+				if err != nil {
+					return nil, err
+				}
+				hashes[hash] = true
+			case StateTypeValidator:
+				hash, err := signatureScriptHash(desc.key)
+				if err != nil {
+					return nil, err
+				}
+				hashes[hash] = true
+			}
+		}
+	}
+
+	if self.txtype == IssueTransaction {
+		issued, err := self.issuedAssets(lookup)
+		if err != nil {
+			return nil, err
+		}
+		for _, assetId := range issued {
+			asset, err := lookupAsset(lookup, assetId)
+			if err != nil {
+				return nil, err
+			}
+			hashes[asset.Issuer] = true
+		}
+	}
+
+	result := make([]UInt160, 0, len(hashes))
+	for hash := range hashes {
+		result = append(result, hash)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Less(result[j]) })
+	return result, nil
+}
+
+// issuedAssets returns the assets whose outputs exceed their inputs.
+func (self *Transaction) issuedAssets(lookup ReferenceLookup) ([]UInt256, error) {
+	balance := make(map[UInt256]int64)
+	var assets []UInt256
+	add := func(assetId UInt256, value int64) {
+		if _, ok := balance[assetId]; !ok {
+			assets = append(assets, assetId)
+		}
+		balance[assetId] += value
+	}
+	for _, input := range self.inputs {
+		output, ok := lookup.GetReference(input)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s:%d", ErrNoReference, input.hash, input.index)
+		}
+		add(output.assetId, output.value.value)
+	}
+	for _, output := range self.outputs {
+		add(output.assetId, -output.value.value)
+	}
+	var issued []UInt256
+	for _, assetId := range assets {
+		if balance[assetId] < 0 {
+			issued = append(issued, assetId)
+		}
+	}
+	return issued, nil
+}
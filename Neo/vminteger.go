@@ -0,0 +1,44 @@
+package Neo
+
+import (
+	"math/big"
+
+	"github.com/neo-thinsdk-go/utils"
+)
+
+// IntegerToBytes encodes n the way NeoVM stores integers: little-endian
+// two's complement in as few bytes as keep the sign, and no bytes for zero.
+func IntegerToBytes(n *big.Int) []byte {
+	switch n.Sign() {
+	case 0:
+		return []byte{}
+	case 1:
+		data := n.Bytes()
+		if data[0]&0x80 != 0 {
+			data = append([]byte{0}, data...)
+		}
+		return utils.BytesReverse(data)
+	}
+	// -2^(8*length-1) <= n, so n+2^(8*length) fits length bytes with the
+	// high bit set
+	abs := new(big.Int).Neg(n)
+	length := new(big.Int).Sub(abs, big.NewInt(1)).BitLen()/8 + 1
+	value := new(big.Int).Lsh(big.NewInt(1), uint(8*length))
+	value.Add(value, n)
+	data := make([]byte, length)
+	value.FillBytes(data)
+	return utils.BytesReverse(data)
+}
+
+// IntegerFromBytes decodes an integer encoded as by IntegerToBytes, such
+// as a ByteArray a contract returned for a number.
+func IntegerFromBytes(data []byte) *big.Int {
+	if len(data) == 0 {
+		return new(big.Int)
+	}
+	value := new(big.Int).SetBytes(utils.BytesReverse(data))
+	if data[len(data)-1]&0x80 != 0 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(8*len(data))))
+	}
+	return value
+}
@@ -0,0 +1,86 @@
+package Neo
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/neo-thinsdk-go/utils"
+)
+
+// Encodings as produced by BigInteger.ToByteArray in the reference
+// implementation, except that NeoVM pushes zero as no bytes.
+var integerVectors = []struct {
+	value   string
+	encoded string
+}{
+	{"0", ""},
+	{"1", "01"},
+	{"-1", "ff"},
+	{"16", "10"},
+	{"127", "7f"},
+	{"128", "8000"},
+	{"-128", "80"},
+	{"-129", "7fff"},
+	{"255", "ff00"},
+	{"256", "0001"},
+	{"32767", "ff7f"},
+	{"32768", "008000"},
+	{"-32768", "0080"},
+	{"-32769", "ff7fff"},
+	{"100000000", "00e1f505"},
+	{"9223372036854775807", "ffffffffffffff7f"},
+	{"-9223372036854775808", "0000000000000080"},
+	{"18446744073709551616", "000000000000000001"},
+}
+
+func TestIntegerToBytes(t *testing.T) {
+	for _, v := range integerVectors {
+		n, _ := new(big.Int).SetString(v.value, 10)
+		if got := utils.ToHexString(IntegerToBytes(n)); got != v.encoded {
+			t.Errorf("IntegerToBytes(%s) = %s, want %s", v.value, got, v.encoded)
+		}
+	}
+}
+
+func TestIntegerFromBytes(t *testing.T) {
+	for _, v := range integerVectors {
+		data, _ := utils.ToBytes(v.encoded)
+		if got := IntegerFromBytes(data).String(); got != v.value {
+			t.Errorf("IntegerFromBytes(%s) = %s, want %s", v.encoded, got, v.value)
+		}
+	}
+	// longer than needed encodings decode to the same value
+	for encoded, want := range map[string]string{"0100": "1", "ffff": "-1", "00": "0"} {
+		data, _ := utils.ToBytes(encoded)
+		if got := IntegerFromBytes(data).String(); got != want {
+			t.Errorf("IntegerFromBytes(%s) = %s, want %s", encoded, got, want)
+		}
+	}
+}
+
+func TestEmitPushNumber(t *testing.T) {
+	tests := []struct {
+		value  int64
+		script string
+	}{
+		{-1, "4f"},
+		{0, "00"},
+		{1, "51"},
+		{16, "60"},
+		{17, "0111"},
+		{-2, "01fe"},
+		{128, "028000"},
+		{100000000, "0400e1f505"},
+	}
+	for _, test := range tests {
+		sb := &ScriptBuilder{}
+		sb.EmitPushNumber(*big.NewInt(test.value))
+		script, err := sb.ToArray()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := utils.ToHexString(script); got != test.script {
+			t.Errorf("EmitPushNumber(%d) = %s, want %s", test.value, got, test.script)
+		}
+	}
+}
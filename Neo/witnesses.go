@@ -0,0 +1,177 @@
+package Neo
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/neo-thinsdk-go/OpCode"
+)
+
+// WitnessResult is the outcome of checking one witness. Index is -1 for
+// a required script hash that has no witness at all.
+type WitnessResult struct {
+	Index      int
+	ScriptHash UInt160
+	Err        error
+}
+
+func (r WitnessResult) Ok() bool {
+	return r.Err == nil
+}
+
+// Witnesses returns a copy of the transaction witnesses.
+func (self *Transaction) Witnesses() []Witness {
+	return append([]Witness(nil), self.witnesses...)
+}
+
+// VerifyWitnesses checks the witnesses of a signed transaction without a
+// node. Standard single and multi signature scripts are verified against
+// GetMessage; any other script is reported with ErrNeedsVM. As on a node,
+// witness i must belong to script hash i of GetScriptHashesForVerifying,
+// so a missing, surplus or misplaced witness is reported too. The returned
+// error is only set when the required hashes cannot be computed.
+func (self *Transaction) VerifyWitnesses(lookup ReferenceLookup) ([]WitnessResult, error) {
+	hashes, err := self.GetScriptHashesForVerifying(lookup)
+	if err != nil {
+		return nil, err
+	}
+	message, err := self.GetMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []WitnessResult
+	for i, witness := range self.witnesses {
+		result := WitnessResult{Index: i}
+		switch {
+		case i >= len(hashes):
+			result.ScriptHash = UInt160FromScript(witness.VerificationScript)
+			result.Err = fmt.Errorf("%w: %d witnesses for %d required script hashes", ErrExtraWitness, len(self.witnesses), len(hashes))
+		case len(witness.VerificationScript) == 0:
+			// the script of a deployed contract is taken from the chain
+			result.ScriptHash = hashes[i]
+			result.Err = ErrNeedsVM
+		default:
+			result.ScriptHash = UInt160FromScript(witness.VerificationScript)
+			if result.ScriptHash != hashes[i] {
+				result.Err = fmt.Errorf("%w: witness of %s where %s is required", ErrWitnessMismatch, result.ScriptHash.Address(), hashes[i].Address())
+			} else {
+				result.Err = verifyWitness(message, &witness)
+			}
+		}
+		results = append(results, result)
+	}
+	for i := len(self.witnesses); i < len(hashes); i++ {
+		hash := hashes[i]
+		results = append(results, WitnessResult{
+			Index:      -1,
+			ScriptHash: hash,
+			Err:        fmt.Errorf("%w: %s", ErrMissingWitness, hash.Address()),
+		})
+	}
+	return results, nil
+}
+
+func verifyWitness(message []byte, witness *Witness) error {
+	signatures, ok := parseSignatures(witness.InvocationScript)
+	if !ok {
+		return fmt.Errorf("%w: invocation script is not a list of signatures", ErrNeedsVM)
+	}
+	if publicKey, ok := parseSignatureScript(witness.VerificationScript); ok {
+		if len(signatures) != 1 {
+			return fmt.Errorf("%w: %d signatures for a single signature script", ErrBadSignature, len(signatures))
+		}
+		return checkSignature(message, signatures[0], publicKey)
+	}
+	if m, publicKeys, ok := parseMultiSigScript(witness.VerificationScript); ok {
+		if len(signatures) != m {
+			return fmt.Errorf("%w: %d signatures, %d required", ErrBadSignature, len(signatures), m)
+		}
+		// CHECKMULTISIG expects the signatures in the order of the keys
+		for i, j := 0, 0; i < len(signatures); j++ {
+			if len(signatures)-i > len(publicKeys)-j {
+				return fmt.Errorf("%w: signature %d matches no remaining key", ErrBadSignature, i)
+			}
+			if checkSignature(message, signatures[i], publicKeys[j]) == nil {
+				i++
+			}
+		}
+		return nil
+	}
+	return ErrNeedsVM
+}
+
+func checkSignature(message []byte, signature []byte, publicKey []byte) error {
+	pubkey, err := DecompressPubkey(publicKey)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBadPublicKey, err)
+	}
+	if !Verify(message, signature, pubkey) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// parseSignatures splits an invocation script made only of 64 byte
+// pushes.
+func parseSignatures(script []byte) ([][]byte, bool) {
+	var signatures [][]byte
+	for len(script) > 0 {
+		if script[0] != 64 || len(script) < 65 {
+			return nil, false
+		}
+		signatures = append(signatures, script[1:65])
+		script = script[65:]
+	}
+	return signatures, len(signatures) > 0
+}
+
+// parseSignatureScript matches PUSHBYTES33 <key> CHECKSIG.
+func parseSignatureScript(script []byte) ([]byte, bool) {
+	if len(script) != 35 || script[0] != 33 || script[34] != OpCode.CHECKSIG {
+		return nil, false
+	}
+	return script[1:34], true
+}
+
+// parseMultiSigScript matches <m> PUSHBYTES33 <key>... <n> CHECKMULTISIG.
+func parseMultiSigScript(script []byte) (int, [][]byte, bool) {
+	m, pos, ok := parseScriptInt(script, 0)
+	if !ok || m < 1 {
+		return 0, nil, false
+	}
+	var publicKeys [][]byte
+	for pos < len(script) && script[pos] == 33 {
+		if pos+34 > len(script) {
+			return 0, nil, false
+		}
+		publicKeys = append(publicKeys, script[pos+1:pos+34])
+		pos += 34
+	}
+	n, pos, ok := parseScriptInt(script, pos)
+	if !ok || n != len(publicKeys) || m > n {
+		return 0, nil, false
+	}
+	if pos != len(script)-1 || script[pos] != OpCode.CHECKMULTISIG {
+		return 0, nil, false
+	}
+	return m, publicKeys, true
+}
+
+// parseScriptInt reads a small count pushed with PUSH1-PUSH16 or as one
+// or two bytes of data.
+func parseScriptInt(script []byte, pos int) (int, int, bool) {
+	if pos >= len(script) {
+		return 0, pos, false
+	}
+	op := script[pos]
+	switch {
+	case op >= OpCode.PUSH1 && op <= OpCode.PUSH16:
+		return int(op-OpCode.PUSH1) + 1, pos + 1, true
+	case op == 1 && pos+2 <= len(script):
+		return int(script[pos+1]), pos + 2, true
+	case op == 2 && pos+3 <= len(script):
+		return int(binary.LittleEndian.Uint16(script[pos+1:])), pos + 3, true
+	}
+	return 0, pos, false
+}
@@ -0,0 +1,157 @@
+package Neo
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+
+	"github.com/neo-thinsdk-go/utils"
+)
+
+// Keys of the NEP-2 specification and of the neon-js test fixtures.
+var keyVectors = []struct {
+	wif       string
+	publicKey string
+	address   string
+}{
+	{
+		wif:       "L3tgppXLgdaeqSGSFw1Go3skBiy8vQAM7YMXvTHsKQtE16PBncSU",
+		publicKey: "035a928f201639204e06b4368b1a93365462a8ebbff0b8818151b74faab3a2b61a",
+		address:   "AXaXZjZGA3qhQRTCsyG5uFKr9HeShgVhTF",
+	},
+	{
+		wif:       "KxDgvEKzgSBPPfuVfw67oPQBSjidEiqTHURKSDL1R7yGaGYAeYnr",
+		publicKey: "031a6c6fbbdf02ca351745fa86b9ba5a9452d785ac4f7fc2b7548ca2a46c4fcf4a",
+		address:   "AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y",
+	},
+}
+
+// signedVector spends output 0 of the genesis miner transaction, owned by
+// the first key, and output 1, owned by the 2 of 2 multisig account of
+// both keys. It was signed with the keys above.
+const (
+	signedVector = "80000002d6572a459b95d9136b7a713c5485ca709f9efa4f08f1c25dd792672d2bd75bfb0000d6572a459b95d913" +
+		"6b7a713c5485ca709f9efa4f08f1c25dd792672d2bd75bfb0100019b7cffdaa674beae0f930ebe6085af9093e5fe56b3" +
+		"4a5c220ccdcf6efc336fc500e1f5050000000023ba2703c53263e8d6e522dc32203339dcd8eee902824000fd214c5d79" +
+		"0bb4f016ddc36a641d2c1bdf6da70530f021639e7842a1d57beda52ad8facc6b0e1fc922b1083fe299e25820a6bb5827" +
+		"b1448d99ef88512d1a48407956a6a313a4d7da97c42f40dd42325c6e7e08439967deee95817a65af0340bfaa9d657a8f" +
+		"679d0ae5fb460c675d45a75e88f478c31545a6db421b00d05e2115475221031a6c6fbbdf02ca351745fa86b9ba5a9452" +
+		"d785ac4f7fc2b7548ca2a46c4fcf4a21035a928f201639204e06b4368b1a93365462a8ebbff0b8818151b74faab3a2b6" +
+		"1a52ae41402dad18245520c998db7983234b29853630f8028ecfadcb3017c83a0b93e221b66b9a48a66e69ac338f27aa" +
+		"2ba4b262c4259303c8d837af832b13441c4c000c222321035a928f201639204e06b4368b1a93365462a8ebbff0b88181" +
+		"51b74faab3a2b61aac"
+	signedTxid     = "0x67289f908ff1da6425dc0afd96375e2645c2f8dae56e75e390e975a48b8f9aee"
+	multiSigScript = "5221031a6c6fbbdf02ca351745fa86b9ba5a9452d785ac4f7fc2b7548ca2a46c4fcf4a21035a928f201639204e06" +
+		"b4368b1a93365462a8ebbff0b8818151b74faab3a2b61a52ae"
+	multiSigAddress = "Ac6f9NPWSjgrfyURReeUaM94PYwzVsFk3m"
+)
+
+func TestKeyVectors(t *testing.T) {
+	for _, v := range keyVectors {
+		key := &ecdsa.PrivateKey{}
+		if err := FromWIF(key, v.wif); err != nil {
+			t.Fatalf("FromWIF(%s): %v", v.wif, err)
+		}
+		if got := utils.ToHexString(CompressPubkey(&key.PublicKey)); got != v.publicKey {
+			t.Errorf("public key %s, want %s", got, v.publicKey)
+		}
+		if got := PublicToAddress(&key.PublicKey); got != v.address {
+			t.Errorf("address %s, want %s", got, v.address)
+		}
+	}
+	script, _ := utils.ToBytes(multiSigScript)
+	if got := UInt160FromScript(script).Address(); got != multiSigAddress {
+		t.Errorf("multisig address %s, want %s", got, multiSigAddress)
+	}
+}
+
+func signedVectorReferences(t *testing.T) References {
+	t.Helper()
+	miner, _ := ParseUInt256(genesisVectors[0].txid)
+	owner, err := UInt160FromAddress(keyVectors[0].address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	multiSig, err := UInt160FromAddress(multiSigAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := Fixed8{value: D}
+	return References{
+		NewTransactionInput(miner, 0): NewTransactionOutput(NeoAssetHash, value, owner),
+		NewTransactionInput(miner, 1): NewTransactionOutput(NeoAssetHash, value, multiSig),
+	}
+}
+
+func TestVerifyWitnesses(t *testing.T) {
+	tx := decodeVector(t, signedVector)
+	if txid, _ := tx.GetTxid(); txid != signedTxid {
+		t.Errorf("txid %s, want %s", txid, signedTxid)
+	}
+	if got := serializeHex(t, tx); got != signedVector {
+		t.Errorf("serialized %s", got)
+	}
+	results, err := tx.VerifyWitnesses(signedVectorReferences(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("%d results, want 2", len(results))
+	}
+	for _, result := range results {
+		if !result.Ok() {
+			t.Errorf("witness %d: %v", result.Index, result.Err)
+		}
+	}
+}
+
+func TestVerifyWitnessesFailures(t *testing.T) {
+	refs := signedVectorReferences(t)
+	tests := []struct {
+		name   string
+		change func(tx *Transaction)
+		want   error
+	}{
+		{"single signature changed", func(tx *Transaction) {
+			tx.witnesses[1].InvocationScript[10] ^= 1
+		}, ErrBadSignature},
+		{"multisig signatures swapped", func(tx *Transaction) {
+			script := tx.witnesses[0].InvocationScript
+			tx.witnesses[0].InvocationScript = append(append([]byte(nil), script[65:]...), script[:65]...)
+		}, ErrBadSignature},
+		{"output changed after signing", func(tx *Transaction) {
+			tx.outputs[0].value = Fixed8{value: 2 * D}
+		}, ErrBadSignature},
+		{"witness missing", func(tx *Transaction) {
+			tx.witnesses = tx.witnesses[:1]
+		}, ErrMissingWitness},
+		{"witness not required", func(tx *Transaction) {
+			tx.inputs = tx.inputs[1:]
+		}, ErrExtraWitness},
+		{"witnesses swapped", func(tx *Transaction) {
+			tx.witnesses[0], tx.witnesses[1] = tx.witnesses[1], tx.witnesses[0]
+		}, ErrWitnessMismatch},
+		{"witness duplicated", func(tx *Transaction) {
+			tx.witnesses = []Witness{tx.witnesses[0], tx.witnesses[0], tx.witnesses[1]}
+		}, ErrWitnessMismatch},
+		{"surplus witness", func(tx *Transaction) {
+			tx.witnesses = append(tx.witnesses, tx.witnesses[1])
+		}, ErrExtraWitness},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx := decodeVector(t, signedVector)
+			test.change(tx)
+			results, err := tx.VerifyWitnesses(refs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, result := range results {
+				if errors.Is(result.Err, test.want) {
+					return
+				}
+			}
+			t.Errorf("results %v, want %v", results, test.want)
+		})
+	}
+}
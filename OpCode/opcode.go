@@ -124,6 +124,7 @@ const (
 	SETITEM   		byte = 0xC4
 	NEWARRAY  		byte = 0xC5 //用作引用類型
 	NEWSTRUCT 		byte = 0xC6 //用作值類型
+	NEWMAP    		byte = 0xC7
 
 	SWITCH 		byte = 0xD0
 
@@ -0,0 +1,243 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrVarIntTooLarge is reported when a var-int exceeds the limit the caller
+// allows for it.
+var ErrVarIntTooLarge = errors.New("var int exceeds limit")
+
+// BinaryReader reads little-endian Neo encodings from an io.Reader. The
+// first error is kept: every later read is skipped and returns zero
+// values, so callers only need to check Err once after a batch of reads.
+// Short reads are reported as io.ErrUnexpectedEOF.
+type BinaryReader struct {
+	r   io.Reader
+	err error
+}
+
+func NewBinaryReader(r io.Reader) *BinaryReader {
+	return &BinaryReader{r: r}
+}
+
+func (br *BinaryReader) Err() error {
+	return br.err
+}
+
+// SetErr records err unless an error was recorded before. Decoders use it
+// to report semantic errors through the same sticky error.
+func (br *BinaryReader) SetErr(err error) {
+	if br.err == nil {
+		br.err = err
+	}
+}
+
+// ReadFull fills data completely.
+func (br *BinaryReader) ReadFull(data []byte) {
+	if br.err != nil {
+		return
+	}
+	if _, err := io.ReadFull(br.r, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		br.err = err
+	}
+}
+
+// ReadBytes reads exactly n bytes.
+func (br *BinaryReader) ReadBytes(n int) []byte {
+	data := make([]byte, n)
+	br.ReadFull(data)
+	if br.err != nil {
+		return nil
+	}
+	return data
+}
+
+func (br *BinaryReader) ReadUint8() uint8 {
+	var data [1]byte
+	br.ReadFull(data[:])
+	if br.err != nil {
+		return 0
+	}
+	return data[0]
+}
+
+func (br *BinaryReader) ReadBool() bool {
+	return br.ReadUint8() != 0
+}
+
+func (br *BinaryReader) ReadUint16() uint16 {
+	var data [2]byte
+	br.ReadFull(data[:])
+	if br.err != nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(data[:])
+}
+
+func (br *BinaryReader) ReadUint32() uint32 {
+	var data [4]byte
+	br.ReadFull(data[:])
+	if br.err != nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(data[:])
+}
+
+func (br *BinaryReader) ReadUint64() uint64 {
+	var data [8]byte
+	br.ReadFull(data[:])
+	if br.err != nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(data[:])
+}
+
+func (br *BinaryReader) ReadInt64() int64 {
+	return int64(br.ReadUint64())
+}
+
+// ReadVarInt reads a var-int and fails with ErrVarIntTooLarge when the
+// value is above max.
+func (br *BinaryReader) ReadVarInt(max uint64) uint64 {
+	fb := br.ReadUint8()
+	var value uint64
+	switch fb {
+	case 0xfd:
+		value = uint64(br.ReadUint16())
+	case 0xfe:
+		value = uint64(br.ReadUint32())
+	case 0xff:
+		value = br.ReadUint64()
+	default:
+		value = uint64(fb)
+	}
+	if br.err != nil {
+		return 0
+	}
+	if value > max {
+		br.err = fmt.Errorf("%w: %d > %d", ErrVarIntTooLarge, value, max)
+		return 0
+	}
+	return value
+}
+
+// ReadVarBytes reads a var-int length prefixed byte array of at most max
+// bytes.
+func (br *BinaryReader) ReadVarBytes(max uint64) []byte {
+	length := br.ReadVarInt(max)
+	if br.err != nil {
+		return nil
+	}
+	return br.ReadBytes(int(length))
+}
+
+func (br *BinaryReader) ReadVarString(max uint64) string {
+	return string(br.ReadVarBytes(max))
+}
+
+// BinaryWriter writes little-endian Neo encodings to an io.Writer. Like
+// BinaryReader it keeps the first error and ignores later writes.
+type BinaryWriter struct {
+	w   io.Writer
+	err error
+}
+
+func NewBinaryWriter(w io.Writer) *BinaryWriter {
+	return &BinaryWriter{w: w}
+}
+
+func (bw *BinaryWriter) Err() error {
+	return bw.err
+}
+
+// SetErr records err unless an error was recorded before.
+func (bw *BinaryWriter) SetErr(err error) {
+	if bw.err == nil {
+		bw.err = err
+	}
+}
+
+// WriteBytes writes data as is, without a length prefix.
+func (bw *BinaryWriter) WriteBytes(data []byte) {
+	if bw.err != nil {
+		return
+	}
+	_, bw.err = bw.w.Write(data)
+}
+
+func (bw *BinaryWriter) WriteUint8(value uint8) {
+	bw.WriteBytes([]byte{value})
+}
+
+func (bw *BinaryWriter) WriteBool(value bool) {
+	if value {
+		bw.WriteUint8(1)
+	} else {
+		bw.WriteUint8(0)
+	}
+}
+
+func (bw *BinaryWriter) WriteUint16(value uint16) {
+	var data [2]byte
+	binary.LittleEndian.PutUint16(data[:], value)
+	bw.WriteBytes(data[:])
+}
+
+func (bw *BinaryWriter) WriteUint32(value uint32) {
+	var data [4]byte
+	binary.LittleEndian.PutUint32(data[:], value)
+	bw.WriteBytes(data[:])
+}
+
+func (bw *BinaryWriter) WriteUint64(value uint64) {
+	var data [8]byte
+	binary.LittleEndian.PutUint64(data[:], value)
+	bw.WriteBytes(data[:])
+}
+
+func (bw *BinaryWriter) WriteInt64(value int64) {
+	bw.WriteUint64(uint64(value))
+}
+
+func (bw *BinaryWriter) WriteVarInt(value uint64) {
+	if value > 0xffffffff {
+		bw.WriteUint8(0xff)
+		bw.WriteUint64(value)
+	} else if value > 0xffff {
+		bw.WriteUint8(0xfe)
+		bw.WriteUint32(uint32(value))
+	} else if value > 0xfc {
+		bw.WriteUint8(0xfd)
+		bw.WriteUint16(uint16(value))
+	} else {
+		bw.WriteUint8(uint8(value))
+	}
+}
+
+func (bw *BinaryWriter) WriteVarBytes(data []byte) {
+	bw.WriteVarInt(uint64(len(data)))
+	bw.WriteBytes(data)
+}
+
+func (bw *BinaryWriter) WriteVarString(value string) {
+	bw.WriteVarBytes([]byte(value))
+}
+
+// GetVarSize returns the number of bytes WriteVarInt uses for value.
+func GetVarSize(value uint64) int {
+	if value > 0xffffffff {
+		return 9
+	} else if value > 0xffff {
+		return 5
+	} else if value > 0xfc {
+		return 3
+	}
+	return 1
+}
@@ -105,28 +105,8 @@ func WriteVarInt(buf *bytes.Buffer, value uint64 )  {
 	}
 }
 
-func ReadVarInt(buf *bytes.Buffer, max uint64) uint64 {
-	fb, _ := buf.ReadByte()
-	var value uint64 = 0
-
-	if fb == 0xfd {
-		data := make([]byte, 2)
-		buf.Read(data)
-
-		value = uint64(binary.LittleEndian.Uint16(data))
-	} else if fb == 0xfe {
-		data := make([]byte, 4)
-		buf.Read(data)
-
-		value = uint64(binary.LittleEndian.Uint32(data))
-	} else if fb == 0xff {
-		data := make([]byte, 8)
-		buf.Read(data)
-
-		value = binary.LittleEndian.Uint64(data)
-	} else {
-		value = uint64(fb)
-	}
-
-	return value
-}
\ No newline at end of file
+func ReadVarInt(buf *bytes.Buffer, max uint64) (uint64, error) {
+	reader := NewBinaryReader(buf)
+	value := reader.ReadVarInt(max)
+	return value, reader.Err()
+}